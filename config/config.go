@@ -6,8 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"docsh/i18n"
+	"docsh/internal/alert"
 )
 
 type Config struct {
@@ -20,6 +22,23 @@ type Config struct {
 	// Banner settings
 	BannerEnabled bool
 	BannerStyle   string
+	// Completion cache settings
+	CompletionCacheTTL    int // seconds
+	CompletionWatchEvents bool
+	// Container context settings (use)
+	CurrentContainer    string
+	PreviousContainer   string
+	RecentContainers    []string
+	ContextAutoSwitch   bool
+	ContextShowInPrompt bool
+	// Container monitor (htop) settings
+	MonitorInterval  time.Duration
+	MonitorRetention time.Duration
+	MonitorSource    string // auto, engine, stream or poll
+	// Threshold alerts evaluated by htop and watch
+	Alerts alert.Config
+	// Warnings are problems found in config.yaml that were skipped; the shell prints them
+	Warnings []string
 }
 
 func NewConfig() *Config {
@@ -31,6 +50,16 @@ func NewConfig() *Config {
 		// Defaults for banner
 		BannerEnabled: true,
 		BannerStyle:   "default",
+		// Defaults for completion cache
+		CompletionCacheTTL:    30,
+		CompletionWatchEvents: true,
+		// Defaults for container context
+		ContextAutoSwitch:   true,
+		ContextShowInPrompt: true,
+		// Defaults for the container monitor: 1s samples, 10 minutes of history
+		MonitorInterval:  time.Second,
+		MonitorRetention: 10 * time.Minute,
+		MonitorSource:    "auto",
 	}
 }
 
@@ -47,7 +76,8 @@ func (c *Config) LoadConfigFile() error {
 		return err
 	}
 
-	return nil
+	// 前回のセッションで use したコンテキストを復元
+	return c.loadContextState()
 }
 
 func (c *Config) loadTraditionalConfig() error {
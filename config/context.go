@@ -0,0 +1,98 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// contextFileName は use で設定したコンテキストをセッション間で保持するファイル名です
+const contextFileName = ".docsh_context"
+
+// MaxRecentContainers は recent_containers に保持する件数の上限です
+const MaxRecentContainers = 10
+
+func contextFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, contextFileName), nil
+}
+
+// loadContextState は保存済みのコンテキストを読み込みます。
+// ファイルが存在する場合は config.yaml の context セクションより優先します。
+func (c *Config) loadContextState() error {
+	path, err := contextFilePath()
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	c.CurrentContainer = ""
+	c.PreviousContainer = ""
+	c.RecentContainers = nil
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		switch strings.TrimSpace(parts[0]) {
+		case "current":
+			c.CurrentContainer = value
+		case "previous":
+			c.PreviousContainer = value
+		case "recent":
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					c.RecentContainers = append(c.RecentContainers, name)
+				}
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// SaveContext は現在のコンテキストを保存します
+func (c *Config) SaveContext() error {
+	path, err := contextFilePath()
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("current=%s\nprevious=%s\nrecent=%s\n",
+		c.CurrentContainer, c.PreviousContainer, strings.Join(c.RecentContainers, ","))
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// SetCurrentContainer は現在のコンテナを切り替え、直前の値を previous に、新しい値を recent の先頭に記録します。
+// 空文字を渡すとコンテキストをクリアします。
+func (c *Config) SetCurrentContainer(name string) {
+	if name == c.CurrentContainer {
+		return
+	}
+	if c.CurrentContainer != "" {
+		c.PreviousContainer = c.CurrentContainer
+	}
+	c.CurrentContainer = name
+	if name == "" {
+		return
+	}
+	recent := []string{name}
+	for _, r := range c.RecentContainers {
+		if r != name && len(recent) < MaxRecentContainers {
+			recent = append(recent, r)
+		}
+	}
+	c.RecentContainers = recent
+}
@@ -5,8 +5,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
+
+	"docsh/i18n"
+	"docsh/internal/alert"
 )
 
 // YAMLConfig represents the YAML configuration structure
@@ -66,8 +71,8 @@ type YAMLConfig struct {
 	Context struct {
 		CurrentContainer string   `yaml:"current_container"`
 		RecentContainers []string `yaml:"recent_containers"`
-		AutoSwitch       bool     `yaml:"auto_switch"`
-		ShowInPrompt     bool     `yaml:"show_in_prompt"`
+		AutoSwitch       *bool    `yaml:"auto_switch"`    // nil keeps the default (on)
+		ShowInPrompt     *bool    `yaml:"show_in_prompt"` // nil keeps the default (on)
 	} `yaml:"context"`
 
 	History struct {
@@ -78,14 +83,29 @@ type YAMLConfig struct {
 	} `yaml:"history"`
 
 	Completion struct {
-		Enabled        bool `yaml:"enabled"`
-		ContainerNames bool `yaml:"container_names"`
-		ImageNames     bool `yaml:"image_names"`
-		CommandOptions bool `yaml:"command_options"`
-		FilePaths      bool `yaml:"file_paths"`
-		MaxSuggestions int  `yaml:"max_suggestions"`
+		Enabled        bool  `yaml:"enabled"`
+		ContainerNames bool  `yaml:"container_names"`
+		ImageNames     bool  `yaml:"image_names"`
+		CommandOptions bool  `yaml:"command_options"`
+		FilePaths      bool  `yaml:"file_paths"`
+		MaxSuggestions int   `yaml:"max_suggestions"`
+		CacheTTL       int   `yaml:"cache_ttl"`
+		WatchEvents    *bool `yaml:"watch_events"` // nil keeps the default (on)
 	} `yaml:"completion"`
 
+	Monitor struct {
+		Interval  string `yaml:"interval"`
+		Retention string `yaml:"retention"`
+		Source    string `yaml:"source"`
+	} `yaml:"monitor"`
+
+	Alerts struct {
+		Bell    bool            `yaml:"bell"`
+		LogFile string          `yaml:"log_file"`
+		Command string          `yaml:"command"`
+		Rules   []yamlAlertRule `yaml:"rules"`
+	} `yaml:"alerts"`
+
 	Themes struct {
 		Default   string `yaml:"default"`
 		Available []struct {
@@ -96,6 +116,15 @@ type YAMLConfig struct {
 	} `yaml:"themes"`
 }
 
+// yamlAlertRule is one entry of alerts.rules
+type yamlAlertRule struct {
+	Name      string  `yaml:"name"`
+	Metric    string  `yaml:"metric"`
+	Above     float64 `yaml:"above"`
+	For       string  `yaml:"for,omitempty"`
+	Container string  `yaml:"container,omitempty"`
+}
+
 // LoadYAMLConfig loads configuration from YAML file
 func (c *Config) LoadYAMLConfig(dataPath string) error {
 	configPath := filepath.Join(dataPath, "config.yaml")
@@ -151,6 +180,66 @@ func (c *Config) mergeYAMLConfig(yamlConfig *YAMLConfig) {
 	if yamlConfig.Banner.Style != "" {
 		c.BannerStyle = yamlConfig.Banner.Style
 	}
+
+	// Completion cache settings
+	if yamlConfig.Completion.CacheTTL > 0 {
+		c.CompletionCacheTTL = yamlConfig.Completion.CacheTTL
+	}
+	if yamlConfig.Completion.WatchEvents != nil {
+		c.CompletionWatchEvents = *yamlConfig.Completion.WatchEvents
+	}
+
+	c.Warnings = nil
+
+	// Container monitor settings (invalid durations keep the defaults with a warning)
+	monitorDuration := func(key, value string, target *time.Duration) {
+		if value == "" {
+			return
+		}
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			*target = d
+			return
+		}
+		c.Warnings = append(c.Warnings, fmt.Sprintf(i18n.T("config.invalid_monitor_duration"), key, value, *target))
+	}
+	monitorDuration("interval", yamlConfig.Monitor.Interval, &c.MonitorInterval)
+	monitorDuration("retention", yamlConfig.Monitor.Retention, &c.MonitorRetention)
+	if yamlConfig.Monitor.Source != "" {
+		c.MonitorSource = yamlConfig.Monitor.Source
+	}
+
+	// Monitor alerts (rules with an unknown metric or duration are skipped with a warning)
+	c.Alerts = alert.Config{Bell: yamlConfig.Alerts.Bell, LogFile: yamlConfig.Alerts.LogFile, Command: yamlConfig.Alerts.Command}
+	for i, r := range yamlConfig.Alerts.Rules {
+		rule := alert.Rule{Name: r.Name, Metric: r.Metric, Above: r.Above, Container: r.Container}
+		if r.For != "" {
+			d, err := time.ParseDuration(r.For)
+			if err != nil || d < 0 {
+				c.Warnings = append(c.Warnings, fmt.Sprintf(i18n.T("config.alert_invalid_for"), i, r.Name, r.For))
+				continue
+			}
+			rule.For = d
+		}
+		if err := rule.Validate(); err != nil {
+			c.Warnings = append(c.Warnings, fmt.Sprintf(i18n.T("config.alert_unknown_metric"), i, r.Name, r.Metric, strings.Join(alert.Metrics, ", ")))
+			continue
+		}
+		c.Alerts.Rules = append(c.Alerts.Rules, rule)
+	}
+
+	// Container context settings
+	if c.CurrentContainer == "" {
+		c.CurrentContainer = yamlConfig.Context.CurrentContainer
+	}
+	if len(c.RecentContainers) == 0 {
+		c.RecentContainers = append([]string{}, yamlConfig.Context.RecentContainers...)
+	}
+	if yamlConfig.Context.AutoSwitch != nil {
+		c.ContextAutoSwitch = *yamlConfig.Context.AutoSwitch
+	}
+	if yamlConfig.Context.ShowInPrompt != nil {
+		c.ContextShowInPrompt = *yamlConfig.Context.ShowInPrompt
+	}
 }
 
 // SaveYAMLConfig saves current configuration to YAML file
@@ -206,10 +295,10 @@ func (c *Config) SaveYAMLConfig(dataPath string) error {
 	yamlConfig.Aliases = c.Aliases
 
 	// Context
-	yamlConfig.Context.CurrentContainer = ""
-	yamlConfig.Context.RecentContainers = []string{}
-	yamlConfig.Context.AutoSwitch = true
-	yamlConfig.Context.ShowInPrompt = true
+	yamlConfig.Context.CurrentContainer = c.CurrentContainer
+	yamlConfig.Context.RecentContainers = append([]string{}, c.RecentContainers...)
+	yamlConfig.Context.AutoSwitch = &c.ContextAutoSwitch
+	yamlConfig.Context.ShowInPrompt = &c.ContextShowInPrompt
 
 	// History
 	yamlConfig.History.MaxEntries = 1000
@@ -224,6 +313,25 @@ func (c *Config) SaveYAMLConfig(dataPath string) error {
 	yamlConfig.Completion.CommandOptions = true
 	yamlConfig.Completion.FilePaths = true
 	yamlConfig.Completion.MaxSuggestions = 16
+	yamlConfig.Completion.CacheTTL = c.CompletionCacheTTL
+	yamlConfig.Completion.WatchEvents = &c.CompletionWatchEvents
+
+	// Container monitor
+	yamlConfig.Monitor.Interval = c.MonitorInterval.String()
+	yamlConfig.Monitor.Retention = c.MonitorRetention.String()
+	yamlConfig.Monitor.Source = c.MonitorSource
+
+	// Monitor alerts
+	yamlConfig.Alerts.Bell = c.Alerts.Bell
+	yamlConfig.Alerts.LogFile = c.Alerts.LogFile
+	yamlConfig.Alerts.Command = c.Alerts.Command
+	for _, r := range c.Alerts.Rules {
+		rule := yamlAlertRule{Name: r.Name, Metric: r.Metric, Above: r.Above, Container: r.Container}
+		if r.For > 0 {
+			rule.For = r.For.String()
+		}
+		yamlConfig.Alerts.Rules = append(yamlConfig.Alerts.Rules, rule)
+	}
 
 	// Themes
 	yamlConfig.Themes.Default = c.Theme
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"docsh/internal/alert"
+)
+
+func loadYAML(t *testing.T, content string) *Config {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewConfig()
+	if err := c.LoadYAMLConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestAlertRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		wantRules    []alert.Rule
+		wantWarnings int
+	}{
+		{
+			name: "valid rules",
+			yaml: `alerts:
+  rules:
+    - name: hot
+      metric: cpu
+      above: 90
+      for: 30s
+    - metric: health
+      container: db
+`,
+			wantRules: []alert.Rule{
+				{Name: "hot", Metric: alert.MetricCPU, Above: 90, For: 30 * time.Second},
+				{Metric: alert.MetricHealth, Container: "db"},
+			},
+		},
+		{
+			name: "unknown metric is skipped",
+			yaml: `alerts:
+  rules:
+    - metric: disk
+      above: 90
+    - metric: mem
+      above: 80
+`,
+			wantRules:    []alert.Rule{{Metric: alert.MetricMem, Above: 80}},
+			wantWarnings: 1,
+		},
+		{
+			name: "invalid for is skipped",
+			yaml: `alerts:
+  rules:
+    - metric: cpu
+      for: soon
+    - metric: cpu
+      for: -1m
+    - metric: restarts
+      above: 3
+`,
+			wantRules:    []alert.Rule{{Metric: alert.MetricRestarts, Above: 3}},
+			wantWarnings: 2,
+		},
+		{
+			name:      "no alerts section",
+			yaml:      "banner:\n  enabled: true\n",
+			wantRules: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := loadYAML(t, tt.yaml)
+			if !reflect.DeepEqual(c.Alerts.Rules, tt.wantRules) {
+				t.Errorf("Rules = %+v, want %+v", c.Alerts.Rules, tt.wantRules)
+			}
+			if len(c.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %q, want %d", c.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestMonitorDurations(t *testing.T) {
+	tests := []struct {
+		name          string
+		yaml          string
+		wantInterval  time.Duration
+		wantRetention time.Duration
+		wantWarnings  int
+	}{
+		{"defaults", "monitor:\n  source: cli\n", time.Second, 10 * time.Minute, 0},
+		{"custom", "monitor:\n  interval: 2s\n  retention: 1h\n", 2 * time.Second, time.Hour, 0},
+		{"invalid keeps the defaults", "monitor:\n  interval: fast\n  retention: 0s\n", time.Second, 10 * time.Minute, 2},
+		{"negative", "monitor:\n  interval: -1s\n", time.Second, 10 * time.Minute, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := loadYAML(t, tt.yaml)
+			if c.MonitorInterval != tt.wantInterval || c.MonitorRetention != tt.wantRetention {
+				t.Errorf("interval/retention = %v/%v, want %v/%v", c.MonitorInterval, c.MonitorRetention, tt.wantInterval, tt.wantRetention)
+			}
+			if len(c.Warnings) != tt.wantWarnings {
+				t.Errorf("Warnings = %q, want %d", c.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
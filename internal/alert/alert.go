@@ -0,0 +1,252 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Metrics a rule can watch
+const (
+	MetricCPU      = "cpu"      // CPU percent
+	MetricMem      = "mem"      // memory percent of the container limit
+	MetricRestarts = "restarts" // restarts since monitoring started
+	MetricHealth   = "health"   // health check reports unhealthy
+)
+
+// Metrics lists the valid metric names
+var Metrics = []string{MetricCPU, MetricMem, MetricRestarts, MetricHealth}
+
+// Rule is one threshold from the alerts section of config.yaml
+type Rule struct {
+	Name      string
+	Metric    string
+	Above     float64       // percent for cpu/mem, restart count for restarts; unused for health
+	For       time.Duration // how long the condition must hold before it fires
+	Container string        // only containers whose name contains this (empty = all)
+}
+
+// Matches reports whether the rule applies to the container
+func (r Rule) Matches(container string) bool {
+	return r.Container == "" || strings.Contains(container, r.Container)
+}
+
+// Describe renders the condition, e.g. "cpu > 90% for 30s"
+func (r Rule) Describe() string {
+	var cond string
+	switch r.Metric {
+	case MetricCPU, MetricMem:
+		cond = fmt.Sprintf("%s > %g%%", r.Metric, r.Above)
+	case MetricRestarts:
+		cond = fmt.Sprintf("restarts > %g", r.Above)
+	case MetricHealth:
+		cond = "unhealthy"
+	default:
+		cond = r.Metric
+	}
+	if r.For > 0 {
+		cond += " for " + r.For.String()
+	}
+	return cond
+}
+
+// Validate checks the metric name
+func (r Rule) Validate() error {
+	for _, m := range Metrics {
+		if r.Metric == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("alert rule %q: unknown metric %q (want %s)", r.Name, r.Metric, strings.Join(Metrics, ", "))
+}
+
+// Config is the alerts section: the rules and where notifications go
+type Config struct {
+	Rules   []Rule
+	Bell    bool   // ring the terminal bell when an alert fires
+	LogFile string // append every firing and resolved alert
+	Command string // run through the system shell with DOCSH_ALERT_* set
+}
+
+// NeedsState reports whether any rule uses inspect data (restarts or health)
+func (c Config) NeedsState() bool {
+	for _, r := range c.Rules {
+		if r.Metric == MetricRestarts || r.Metric == MetricHealth {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is an alert that started firing or was resolved
+type Event struct {
+	Rule      Rule
+	Container string
+	Value     float64
+	Firing    bool
+	At        time.Time
+}
+
+// Message describes the event in one line
+func (e Event) Message() string {
+	value := ""
+	switch e.Rule.Metric {
+	case MetricCPU, MetricMem:
+		value = fmt.Sprintf(" (now %.1f%%)", e.Value)
+	case MetricRestarts:
+		value = fmt.Sprintf(" (now %.0f)", e.Value)
+	}
+	name := ""
+	if e.Rule.Name != "" {
+		name = "[" + e.Rule.Name + "] "
+	}
+	return fmt.Sprintf("%s%s: %s%s", name, e.Container, e.Rule.Describe(), value)
+}
+
+type key struct {
+	rule      int
+	container string
+}
+
+// Tracker remembers which rule/container pairs are firing so that each
+// transition is reported once
+type Tracker struct {
+	rules  []Rule
+	active map[key]Event
+}
+
+func NewTracker(rules []Rule) *Tracker {
+	return &Tracker{rules: rules, active: map[key]Event{}}
+}
+
+// Rules returns the rules being tracked
+func (t *Tracker) Rules() []Rule { return t.rules }
+
+// Observe records whether rule i holds for the container and returns an event on a change
+func (t *Tracker) Observe(i int, container string, holds bool, value float64, now time.Time) (Event, bool) {
+	k := key{i, container}
+	prev, firing := t.active[k]
+	switch {
+	case holds && !firing:
+		e := Event{Rule: t.rules[i], Container: container, Value: value, Firing: true, At: now}
+		t.active[k] = e
+		return e, true
+	case holds:
+		prev.Value = value
+		t.active[k] = prev
+	case firing:
+		delete(t.active, k)
+		return Event{Rule: t.rules[i], Container: container, Value: value, At: now}, true
+	}
+	return Event{}, false
+}
+
+// Forget resolves every alert of a container that went away
+func (t *Tracker) Forget(present map[string]bool, now time.Time) []Event {
+	var events []Event
+	for k, e := range t.active {
+		if !present[k.container] {
+			delete(t.active, k)
+			events = append(events, Event{Rule: e.Rule, Container: e.Container, Value: e.Value, At: now})
+		}
+	}
+	return events
+}
+
+// Active returns the firing alerts, oldest first
+func (t *Tracker) Active() []Event {
+	events := make([]Event, 0, len(t.active))
+	for _, e := range t.active {
+		events = append(events, e)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].At.Equal(events[j].At) {
+			return events[i].At.Before(events[j].At)
+		}
+		return events[i].Container < events[j].Container
+	})
+	return events
+}
+
+// ActiveFor returns the firing alerts of one container
+func (t *Tracker) ActiveFor(container string) []Event {
+	var events []Event
+	for _, e := range t.Active() {
+		if e.Container == container {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// commandTimeout bounds a notification hook
+const commandTimeout = 10 * time.Second
+
+// Notify sends an event to the bell, the log file and the command hook.
+// The hook runs in the background; only log file errors are returned.
+func (c Config) Notify(e Event, bell io.Writer) error {
+	if c.Bell && e.Firing && bell != nil {
+		fmt.Fprint(bell, "\a")
+	}
+	if c.Command != "" {
+		go runHook(c.Command, e)
+	}
+	if c.LogFile == "" {
+		return nil
+	}
+	path := expandHome(c.LogFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%s %s %s\n", e.At.Format(time.RFC3339), state(e), e.Message())
+	return err
+}
+
+func state(e Event) string {
+	if e.Firing {
+		return "FIRING"
+	}
+	return "RESOLVED"
+}
+
+// runHook runs the command hook with the event in its environment
+func runHook(command string, e Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(),
+		"DOCSH_ALERT_STATE="+strings.ToLower(state(e)),
+		"DOCSH_ALERT_RULE="+e.Rule.Name,
+		"DOCSH_ALERT_METRIC="+e.Rule.Metric,
+		"DOCSH_ALERT_CONTAINER="+e.Container,
+		fmt.Sprintf("DOCSH_ALERT_VALUE=%.1f", e.Value),
+		"DOCSH_ALERT_MESSAGE="+e.Message(),
+	)
+	cmd.Run()
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,161 @@
+package alert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRule(t *testing.T) {
+	tests := []struct {
+		rule      Rule
+		valid     bool
+		describe  string
+		container string
+		matches   bool
+	}{
+		{Rule{Metric: MetricCPU, Above: 90, For: 30 * time.Second}, true, "cpu > 90% for 30s", "web-1", true},
+		{Rule{Metric: MetricMem, Above: 80.5, Container: "db"}, true, "mem > 80.5%", "shop-db-1", true},
+		{Rule{Metric: MetricMem, Above: 80, Container: "db"}, true, "mem > 80%", "web-1", false},
+		{Rule{Metric: MetricRestarts, Above: 3}, true, "restarts > 3", "api", true},
+		{Rule{Metric: MetricHealth, For: time.Minute}, true, "unhealthy for 1m0s", "api", true},
+		{Rule{Name: "disk", Metric: "disk"}, false, "disk", "api", true},
+		{Rule{Metric: ""}, false, "", "api", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.describe, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tt.valid)
+			}
+			if got := tt.rule.Describe(); got != tt.describe {
+				t.Errorf("Describe() = %q, want %q", got, tt.describe)
+			}
+			if got := tt.rule.Matches(tt.container); got != tt.matches {
+				t.Errorf("Matches(%q) = %v, want %v", tt.container, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestEventMessage(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{Event{Rule: Rule{Name: "hot", Metric: MetricCPU, Above: 90}, Container: "web", Value: 95.25}, "[hot] web: cpu > 90% (now 95.2%)"},
+		{Event{Rule: Rule{Metric: MetricRestarts, Above: 2}, Container: "api", Value: 3}, "api: restarts > 2 (now 3)"},
+		{Event{Rule: Rule{Metric: MetricHealth}, Container: "db"}, "db: unhealthy"},
+	}
+	for _, tt := range tests {
+		if got := tt.event.Message(); got != tt.want {
+			t.Errorf("Message() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestTrackerTransitions(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	type step struct {
+		holds      bool
+		value      float64
+		wantEvent  bool
+		wantFiring bool // of the event, when there is one
+		wantActive int
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "fires once while the condition holds",
+			steps: []step{
+				{holds: false, value: 10},
+				{holds: true, value: 95, wantEvent: true, wantFiring: true, wantActive: 1},
+				{holds: true, value: 97, wantActive: 1},
+				{holds: true, value: 99, wantActive: 1},
+			},
+		},
+		{
+			name: "resolves once when it stops holding",
+			steps: []step{
+				{holds: true, value: 95, wantEvent: true, wantFiring: true, wantActive: 1},
+				{holds: false, value: 20, wantEvent: true, wantFiring: false},
+				{holds: false, value: 10},
+			},
+		},
+		{
+			name: "flapping reports every transition",
+			steps: []step{
+				{holds: true, wantEvent: true, wantFiring: true, wantActive: 1},
+				{holds: false, wantEvent: true},
+				{holds: true, wantEvent: true, wantFiring: true, wantActive: 1},
+				{holds: false, wantEvent: true},
+			},
+		},
+		{
+			name:  "never holding is silent",
+			steps: []step{{holds: false}, {holds: false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTracker([]Rule{{Metric: MetricCPU, Above: 90}})
+			for i, s := range tt.steps {
+				now := start.Add(time.Duration(i) * time.Second)
+				e, changed := tracker.Observe(0, "web", s.holds, s.value, now)
+				if changed != s.wantEvent {
+					t.Fatalf("step %d: changed = %v, want %v", i, changed, s.wantEvent)
+				}
+				if changed && (e.Firing != s.wantFiring || e.Container != "web" || !e.At.Equal(now) || e.Value != s.value) {
+					t.Errorf("step %d: event = %+v, want firing %v at %v", i, e, s.wantFiring, now)
+				}
+				if got := len(tracker.Active()); got != s.wantActive {
+					t.Errorf("step %d: %d active, want %d", i, got, s.wantActive)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackerKeepsFirstFiringTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker([]Rule{{Metric: MetricCPU, Above: 90}})
+	tracker.Observe(0, "web", true, 91, start)
+	tracker.Observe(0, "web", true, 98, start.Add(time.Minute))
+	active := tracker.Active()
+	if len(active) != 1 || !active[0].At.Equal(start) || active[0].Value != 98 {
+		t.Errorf("Active() = %+v, want one alert since %v with the latest value 98", active, start)
+	}
+}
+
+func TestTrackerPerContainerAndForget(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker([]Rule{{Metric: MetricCPU, Above: 90}, {Metric: MetricHealth}})
+	tracker.Observe(0, "web", true, 95, now)
+	tracker.Observe(1, "web", true, 0, now.Add(time.Second))
+	tracker.Observe(0, "api", true, 92, now.Add(2*time.Second))
+
+	if got := len(tracker.ActiveFor("web")); got != 2 {
+		t.Errorf("ActiveFor(web) = %d alerts, want 2", got)
+	}
+	active := tracker.Active()
+	if len(active) != 3 || active[0].Container != "web" || active[2].Container != "api" {
+		t.Errorf("Active() = %+v, want oldest first", active)
+	}
+
+	resolved := tracker.Forget(map[string]bool{"api": true}, now.Add(time.Minute))
+	if len(resolved) != 2 {
+		t.Fatalf("Forget() resolved %d alerts, want the 2 of web", len(resolved))
+	}
+	for _, e := range resolved {
+		if e.Firing || e.Container != "web" {
+			t.Errorf("Forget() event = %+v, want web resolved", e)
+		}
+	}
+	if got := tracker.Active(); len(got) != 1 || got[0].Container != "api" {
+		t.Errorf("Active() after Forget = %+v, want only api", got)
+	}
+	// a container that comes back fires again
+	if _, changed := tracker.Observe(0, "web", true, 95, now.Add(2*time.Minute)); !changed {
+		t.Error("Observe() after Forget did not fire again")
+	}
+}
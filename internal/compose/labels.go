@@ -0,0 +1,48 @@
+package compose
+
+import (
+	"strings"
+)
+
+// Labels set by Docker Compose on the containers it creates
+const (
+	ProjectLabel    = "com.docker.compose.project"
+	WorkingDirLabel = "com.docker.compose.project.working_dir"
+	ServiceLabel    = "com.docker.compose.service"
+	DependsOnLabel  = "com.docker.compose.depends_on"
+)
+
+// ServiceName returns the compose service of a container. When the service label is
+// missing it is guessed from names like "<project>-<service>-1" or "<project>_<service>_1".
+func ServiceName(containerName, project, service string) string {
+	if service != "" || project == "" {
+		return service
+	}
+	base := containerName
+	if strings.HasPrefix(containerName, project+"-") {
+		// hyphen separated: project-service-index
+		parts := strings.Split(containerName, "-")
+		if len(parts) >= 2 {
+			base = parts[1]
+		}
+	} else if strings.HasPrefix(containerName, project+"_") {
+		// underscore separated: project_service_index
+		parts := strings.Split(containerName, "_")
+		if len(parts) >= 2 {
+			base = parts[1]
+		}
+	}
+	// drop the index suffix
+	base = strings.TrimSuffix(base, "-1")
+	base = strings.TrimSuffix(base, "_1")
+	if base != "" && base != project {
+		return base
+	}
+	return ""
+}
+
+// FromLabels returns the project and service of a container from its labels
+func FromLabels(containerName string, labels map[string]string) (project, service string) {
+	project = strings.TrimSpace(labels[ProjectLabel])
+	return project, ServiceName(containerName, project, strings.TrimSpace(labels[ServiceLabel]))
+}
@@ -23,6 +23,51 @@ type CommandMapping struct {
 	Warnings      []string          `json:"warnings,omitempty" yaml:"warnings,omitempty"`
 	LocalizedDescription map[string]string `json:"localized_description,omitempty" yaml:"localized_description,omitempty"`
 	LocalizedNotes       map[string][]string `json:"localized_notes,omitempty" yaml:"localized_notes,omitempty"`
+	LocalizedWarnings    map[string][]string `json:"localized_warnings,omitempty" yaml:"localized_warnings,omitempty"`
+	Options              []MappingOption     `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// MappingOption describes an option accepted by the mapped Docker command.
+// Type is one of container, image, service, duration, int, enum or string; empty means a flag.
+type MappingOption struct {
+	Name                 string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Short                string            `json:"short,omitempty" yaml:"short,omitempty"`
+	Type                 string            `json:"type,omitempty" yaml:"type,omitempty"`
+	Values               []string          `json:"values,omitempty" yaml:"values,omitempty"`
+	Description          string            `json:"description,omitempty" yaml:"description,omitempty"`
+	LocalizedDescription map[string]string `json:"localized_description,omitempty" yaml:"localized_description,omitempty"`
+}
+
+// DescriptionFor returns the option description in the given language, falling back to Description
+func (o *MappingOption) DescriptionFor(lang string) string {
+	if desc, ok := o.LocalizedDescription[lang]; ok && desc != "" {
+		return desc
+	}
+	return o.Description
+}
+
+// DescriptionFor returns the description in the given language, falling back to Description
+func (m *CommandMapping) DescriptionFor(lang string) string {
+	if desc, ok := m.LocalizedDescription[lang]; ok && desc != "" {
+		return desc
+	}
+	return m.Description
+}
+
+// NotesFor returns the notes in the given language, falling back to Notes
+func (m *CommandMapping) NotesFor(lang string) []string {
+	if notes, ok := m.LocalizedNotes[lang]; ok && len(notes) > 0 {
+		return notes
+	}
+	return m.Notes
+}
+
+// WarningsFor returns the warnings in the given language, falling back to Warnings
+func (m *CommandMapping) WarningsFor(lang string) []string {
+	if warnings, ok := m.LocalizedWarnings[lang]; ok && len(warnings) > 0 {
+		return warnings
+	}
+	return m.Warnings
 }
 
 // MappingEngine defines the interface for command mapping operations
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"docsh/internal/compose"
+)
+
+// Types lists the object types `docker events --filter type=` accepts
+var Types = []string{"container", "image", "network", "volume", "daemon", "plugin", "service", "node", "secret", "config"}
+
+// Event is one line of `docker events --format '{{json .}}'`
+type Event struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	Scope    string `json:"scope"`
+	TimeNano int64  `json:"timeNano"`
+}
+
+// Parse decodes one JSON line
+func Parse(line string) (Event, error) {
+	var e Event
+	err := json.Unmarshal([]byte(line), &e)
+	return e, err
+}
+
+// Time is when the daemon reported the event
+func (e Event) Time() time.Time { return time.Unix(0, e.TimeNano) }
+
+// Verb is the action without its detail, e.g. "health_status" for "health_status: unhealthy"
+func (e Event) Verb() string {
+	verb, _, _ := strings.Cut(e.Action, ":")
+	return strings.TrimSpace(verb)
+}
+
+// Detail is the part of the action after the colon ("unhealthy", or the exec command)
+func (e Event) Detail() string {
+	_, detail, _ := strings.Cut(e.Action, ":")
+	return strings.TrimSpace(detail)
+}
+
+// Name is the name of the object, falling back to a short ID
+func (e Event) Name() string {
+	if name := e.Actor.Attributes["name"]; name != "" {
+		return name
+	}
+	if len(e.Actor.ID) > 12 && !strings.Contains(e.Actor.ID, ":") {
+		return e.Actor.ID[:12]
+	}
+	return e.Actor.ID
+}
+
+// Compose returns the project and service of a container event (the labels are attributes)
+func (e Event) Compose() (project, service string) {
+	if e.Type != "container" {
+		return "", ""
+	}
+	return compose.FromLabels(e.Name(), e.Actor.Attributes)
+}
+
+// ExitCode is the exit code of a die event ("" for other events)
+func (e Event) ExitCode() string { return e.Actor.Attributes["exitCode"] }
+
+// noisy are container actions docsh itself causes all the time (every ls or cat is an exec)
+var noisy = map[string]bool{
+	"exec_create": true, "exec_start": true, "exec_die": true, "exec_detach": true,
+	"attach": true, "detach": true, "resize": true, "top": true, "archive-path": true,
+	"extract-to-dir": true, "copy": true, "export": true, "commit": true,
+}
+
+// Lifecycle reports whether the event changes the state of an object, leaving out
+// execs, attaches and file copies
+func (e Event) Lifecycle() bool {
+	return !(e.Type == "container" && noisy[e.Verb()])
+}
+
+// Color is the color name of the event (names understood by themes.Colorize):
+// green for things coming up, red for failures, yellow for stops and restarts
+func (e Event) Color() string {
+	switch e.Verb() {
+	case "start", "unpause", "connect", "mount":
+		return "green"
+	case "oom", "kill":
+		return "red"
+	case "die":
+		if code := e.ExitCode(); code != "" && code != "0" {
+			return "red"
+		}
+		return "yellow"
+	case "stop", "restart", "pause", "disconnect", "unmount":
+		return "yellow"
+	case "health_status":
+		switch e.Detail() {
+		case "healthy":
+			return "green"
+		case "unhealthy":
+			return "red"
+		}
+		return "yellow"
+	case "create", "pull", "tag", "load", "import":
+		return "cyan"
+	case "destroy", "delete", "untag", "remove", "prune":
+		return "magenta"
+	}
+	return "blue"
+}
+
+// Filter narrows the stream; empty fields do not filter
+type Filter struct {
+	Types      []string
+	Containers []string
+	Project    string
+	Since      string // passed to --since (a duration like 10m or a timestamp)
+	Until      string
+}
+
+// Args returns the docker events arguments for the filter
+func (f Filter) Args() []string {
+	args := []string{"events", "--format", "{{json .}}"}
+	for _, t := range f.Types {
+		args = append(args, "--filter", "type="+t)
+	}
+	for _, c := range f.Containers {
+		args = append(args, "--filter", "container="+c)
+	}
+	if f.Project != "" {
+		args = append(args, "--filter", "label="+compose.ProjectLabel+"="+f.Project)
+	}
+	if f.Since != "" {
+		args = append(args, "--since", f.Since)
+	}
+	if f.Until != "" {
+		args = append(args, "--until", f.Until)
+	}
+	return args
+}
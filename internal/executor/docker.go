@@ -9,10 +9,13 @@ import (
 	"os/signal"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"docsh/i18n"
 	"docsh/internal/engine"
+	"docsh/internal/health"
 	"docsh/internal/parser"
+	"docsh/themes"
 )
 
 // ExecutionResult represents the result of command execution
@@ -68,9 +71,12 @@ func (executor *DefaultShellExecutor) Execute(ctx context.Context, cmd *parser.P
 		return result, nil
 	}
 
-	// Handle different command types
+	// Builtin commands are dispatched by the shell's command registry
 	if cmd.IsBuiltin {
-		return executor.executeBuiltinCommand(ctx, cmd)
+		result.Error = i18n.T("app.unknown_builtin", cmd.Command)
+		result.ExitCode = 1
+		result.Duration = time.Since(start)
+		return result, fmt.Errorf(result.Error)
 	}
 
 	if cmd.IsLinux {
@@ -243,35 +249,6 @@ func (executor *DefaultShellExecutor) IsDockerAvailable() bool {
 	return err == nil
 }
 
-// executeBuiltinCommand executes builtin commands
-func (executor *DefaultShellExecutor) executeBuiltinCommand(ctx context.Context, cmd *parser.ParsedCommand) (*ExecutionResult, error) {
-	start := time.Now()
-	result := &ExecutionResult{
-		Command: cmd.Command,
-	}
-
-	switch cmd.Command {
-	case "help":
-		result.Output = executor.getHelpText()
-	case "version":
-		result.Output = "Docsh version 1.0.0"
-	case "mapping":
-		output, err := executor.handleMappingCommand(cmd.Args)
-		if err != nil {
-			result.Error = err.Error()
-			result.ExitCode = 1
-		} else {
-			result.Output = output
-		}
-	default:
-		result.Error = fmt.Sprintf("Unknown builtin command: %s", cmd.Command)
-		result.ExitCode = 1
-	}
-
-	result.Duration = time.Since(start)
-	return result, nil
-}
-
 // executeSystemCommand executes system commands
 func (executor *DefaultShellExecutor) executeSystemCommand(ctx context.Context, cmd *parser.ParsedCommand) (*ExecutionResult, error) {
 	start := time.Now()
@@ -314,8 +291,12 @@ func (executor *DefaultShellExecutor) executeDockerCommand(ctx context.Context,
 		return result, fmt.Errorf(i18n.T("docker.not_available"))
 	}
 
-	// Prepend "docker" if not already present
+	// Pass docker arguments through verbatim so options keep their position and values
 	args := cmd.Args
+	if cmd.RawArgs != nil {
+		args = cmd.RawArgs
+	}
+	// Prepend "docker" if not already present
 	if cmd.Command != "docker" {
 		args = append([]string{cmd.Command}, args...)
 	}
@@ -346,161 +327,6 @@ func (executor *DefaultShellExecutor) executeDockerCommand(ctx context.Context,
 	return result, nil
 }
 
-// handleMappingCommand handles mapping-related commands
-func (executor *DefaultShellExecutor) handleMappingCommand(args []string) (string, error) {
-	if len(args) == 0 {
-		return executor.listAllMappings(), nil
-	}
-
-	switch args[0] {
-	case "list":
-		if len(args) > 1 {
-			return executor.listMappingsByCategory(args[1])
-		}
-		return executor.listAllMappings(), nil
-	case "search":
-		if len(args) > 1 {
-			return executor.searchMappings(strings.Join(args[1:], " "))
-		}
-		return "", fmt.Errorf(i18n.T("mapping.search_requires_query"))
-	case "show":
-		if len(args) > 1 {
-			return executor.showMapping(args[1])
-		}
-		return "", fmt.Errorf(i18n.T("mapping.show_requires_command"))
-	default:
-		return "", fmt.Errorf(i18n.T("mapping.unknown_command")+": %s", args[0])
-	}
-}
-
-// listAllMappings lists all available mappings
-func (executor *DefaultShellExecutor) listAllMappings() string {
-	var output strings.Builder
-
-	output.WriteString(i18n.T("commands.docker_only_mappings_title") + "\n\n")
-
-	categories := executor.mappingEngine.GetCategories()
-	for _, category := range categories {
-		output.WriteString(fmt.Sprintf("=== %s ===\n", i18n.T("categories."+category)))
-		categoryMappings, _ := executor.mappingEngine.ListByCategory(category)
-		for _, mapping := range categoryMappings {
-			output.WriteString(fmt.Sprintf("  %s -> %s\n", mapping.LinuxCommand, mapping.DockerCommand))
-			output.WriteString(fmt.Sprintf("    %s\n", mapping.Description))
-		}
-		output.WriteString("\n")
-	}
-
-	return output.String()
-}
-
-// listMappingsByCategory lists mappings for a specific category
-func (executor *DefaultShellExecutor) listMappingsByCategory(category string) (string, error) {
-	mappings, err := executor.mappingEngine.ListByCategory(category)
-	if err != nil {
-		return "", err
-	}
-
-	if len(mappings) == 0 {
-		return fmt.Sprintf(i18n.T("mappings.category_not_found"), category), nil
-	}
-
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf(i18n.T("commands.mapping_list")+" '%s':\n\n", i18n.T("categories."+category)))
-
-	for _, mapping := range mappings {
-		output.WriteString(fmt.Sprintf("%s -> %s\n", mapping.LinuxCommand, mapping.DockerCommand))
-		output.WriteString(fmt.Sprintf("  %s: %s\n", i18n.T("help.description"), mapping.Description))
-		output.WriteString(fmt.Sprintf("  %s: %s\n", i18n.T("examples.basic_usage"), mapping.DockerExample))
-		if len(mapping.Notes) > 0 {
-			output.WriteString(fmt.Sprintf("  Notes: %s\n", strings.Join(mapping.Notes, ", ")))
-		}
-		output.WriteString("\n")
-	}
-
-	return output.String(), nil
-}
-
-// searchMappings searches for mappings by query
-func (executor *DefaultShellExecutor) searchMappings(query string) (string, error) {
-	mappings, err := executor.mappingEngine.SearchCommands(query)
-	if err != nil {
-		return "", err
-	}
-
-	if len(mappings) == 0 {
-		return fmt.Sprintf(i18n.T("mappings.search_no_results"), query), nil
-	}
-
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf(i18n.T("commands.mapping_search")+": '%s'\n\n", query))
-
-	for _, mapping := range mappings {
-		output.WriteString(fmt.Sprintf("%s -> %s\n", mapping.LinuxCommand, mapping.DockerCommand))
-		output.WriteString(fmt.Sprintf("  %s: %s\n", i18n.T("categories.container-management"), i18n.T("categories."+mapping.Category)))
-		output.WriteString(fmt.Sprintf("  Description: %s\n", mapping.Description))
-		output.WriteString("\n")
-	}
-
-	return output.String(), nil
-}
-
-// showMapping shows details for a specific mapping
-func (executor *DefaultShellExecutor) showMapping(command string) (string, error) {
-	mapping, err := executor.mappingEngine.FindByLinuxCommand(command)
-	if err != nil {
-		mapping, err = executor.mappingEngine.FindByDockerCommand(command)
-		if err != nil {
-			return "", fmt.Errorf(i18n.T("mappings.not_found"), command)
-		}
-	}
-
-	var output strings.Builder
-	output.WriteString(fmt.Sprintf(i18n.T("commands.mapping_show")+": '%s'\n\n", command))
-	output.WriteString(fmt.Sprintf("Linux: %s\n", mapping.LinuxCommand))
-	output.WriteString(fmt.Sprintf("Docker: %s\n", mapping.DockerCommand))
-	output.WriteString(fmt.Sprintf("%s: %s\n", i18n.T("categories.container-management"), i18n.T("categories."+mapping.Category)))
-	output.WriteString(fmt.Sprintf("%s: %s\n", i18n.T("help.description"), mapping.Description))
-	output.WriteString(fmt.Sprintf("Linux: %s\n", mapping.LinuxExample))
-	output.WriteString(fmt.Sprintf("Docker: %s\n", mapping.DockerExample))
-
-	if len(mapping.Notes) > 0 {
-		output.WriteString("\n" + i18n.T("help.notes") + ":\n")
-		for _, note := range mapping.Notes {
-			output.WriteString(fmt.Sprintf("  - %s\n", note))
-		}
-	}
-
-	if len(mapping.Warnings) > 0 {
-		output.WriteString("\n" + i18n.T("help.warnings") + ":\n")
-		for _, warning := range mapping.Warnings {
-			output.WriteString(fmt.Sprintf("  ⚠️  %s\n", warning))
-		}
-	}
-
-	return output.String(), nil
-}
-
-// getHelpText returns help text for the shell
-func (executor *DefaultShellExecutor) getHelpText() string {
-	var b strings.Builder
-	b.WriteString(i18n.T("commands.help_title") + "\n\n")
-	b.WriteString(i18n.T("commands.help_description") + "\n\n")
-	b.WriteString(i18n.T("help.usage") + ":\n")
-	b.WriteString("  help\n")
-	b.WriteString("  version\n")
-	b.WriteString("  mapping [list|search|show] <args>\n")
-	b.WriteString("  exit\n\n")
-	b.WriteString(i18n.T("commands.examples_header") + ":\n")
-	b.WriteString(i18n.T("commands.examples_ls") + "\n")
-	b.WriteString(i18n.T("commands.examples_ps") + "\n")
-	b.WriteString(i18n.T("commands.examples_kill") + "\n")
-	b.WriteString(i18n.T("commands.examples_rm") + "\n")
-	b.WriteString(i18n.T("commands.examples_tail") + "\n\n")
-	b.WriteString(i18n.T("commands.docker_only_more_info_title") + "\n")
-	b.WriteString("  mapping show <command>\n")
-	return b.String()
-}
-
 // executeStreamingCommand executes streaming commands like docker logs -f with real-time output
 func (executor *DefaultShellExecutor) executeStreamingCommand(ctx context.Context, dockerCmd []string, result *ExecutionResult) (*ExecutionResult, error) {
 	start := time.Now()
@@ -830,7 +656,11 @@ func (executor *DefaultShellExecutor) formatSimplePsOutput(output string) string
 	portsPos := strings.Index(header, "PORTS")
 	namesPos := strings.Index(header, "NAMES")
 
-	var result strings.Builder
+	type psRow struct {
+		status      health.Status
+		name, ports string
+	}
+	var rows []psRow
 
 	// Process each container line (skip header)
 	for i := 1; i < len(lines); i++ {
@@ -861,50 +691,53 @@ func (executor *DefaultShellExecutor) formatSimplePsOutput(output string) string
 			ports = strings.TrimSpace(line[portsPos:namesPos])
 		}
 
-		// Clean up status (remove extra info, keep main status)
-		status = executor.cleanStatus(status)
-
 		// Clean up ports (remove empty or "-" entries)
 		ports = executor.cleanPorts(ports)
 
-		// Format output: STATUS NAME PORT
-		if ports != "" {
-			result.WriteString(fmt.Sprintf("%-10s %-20s %s\n", status, name, ports))
-		} else {
-			result.WriteString(fmt.Sprintf("%-10s %s\n", status, name))
+		rows = append(rows, psRow{status: health.FromPS(status), name: name, ports: ports})
+	}
+
+	// The STATUS column has no restart counts, OOM kills or start errors; one inspect
+	// of the containers that stopped or keep restarting fills them in (ps output stays
+	// as is if it fails), so a host full of running containers costs no extra call
+	var names []string
+	for _, row := range rows {
+		if row.name != "" && row.status.NeedsInspect() {
+			names = append(names, row.name)
+		}
+	}
+	if containers, err := health.Inspect(names...); err == nil {
+		byName := make(map[string]health.Status, len(containers))
+		for _, c := range containers {
+			byName[c.Name] = c.Status()
+		}
+		for i, row := range rows {
+			if st, ok := byName[row.name]; ok {
+				rows[i].status = st
+			}
 		}
 	}
 
-	return result.String()
-}
+	// Pad before coloring so the escape codes do not break the columns
+	width := 10
+	for _, row := range rows {
+		width = max(width, utf8.RuneCountInString(row.status.Summary()))
+	}
 
-// cleanStatus extracts the main status from docker ps status field and applies color coding
-func (executor *DefaultShellExecutor) cleanStatus(status string) string {
-	status = strings.TrimSpace(status)
-	if status == "" {
-		return "unknown"
-	}
-
-	// Extract main status word (Up, Exited, Created, etc.)
-	words := strings.Fields(status)
-	if len(words) > 0 {
-		mainStatus := words[0]
-		// Handle special cases with color coding
-		switch strings.ToLower(mainStatus) {
-		case "up":
-			return "\033[32mrunning\033[0m" // Green color for running
-		case "exited":
-			// Keep exit code if available: "Exited (0)"
-			if len(words) > 1 && strings.Contains(words[1], "(") {
-				return "\033[33mexited" + words[1] + "\033[0m" // Orange color for exited
-			}
-			return "\033[33mexited\033[0m" // Orange color for exited
-		default:
-			return strings.ToLower(mainStatus)
+	var result strings.Builder
+	for _, row := range rows {
+		summary := row.status.Summary()
+		status := themes.Colorize(summary, row.status.Color()) + strings.Repeat(" ", width-utf8.RuneCountInString(summary))
+
+		// Format output: STATUS NAME PORT
+		if row.ports != "" {
+			result.WriteString(fmt.Sprintf("%s %-20s %s\n", status, row.name, row.ports))
+		} else {
+			result.WriteString(fmt.Sprintf("%s %s\n", status, row.name))
 		}
 	}
 
-	return strings.ToLower(status)
+	return result.String()
 }
 
 // cleanPorts cleans and formats port information
@@ -0,0 +1,336 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status is what docsh shows about a container beyond running or exited
+type Status struct {
+	State        string // running, exited, restarting, created, paused or dead
+	Health       string // starting, healthy or unhealthy; empty without a health check
+	RestartCount int
+	OOMKilled    bool
+	ExitCode     int
+	Error        string // the daemon's error when the container failed to start
+}
+
+// Probe is one entry of the health check log
+type Probe struct {
+	Start    string
+	End      string
+	ExitCode int
+	Output   string
+}
+
+// Container is the part of `docker inspect` that `health` and the htop detail view need
+type Container struct {
+	Name         string
+	RestartCount int
+	Config       struct {
+		Image       string
+		Env         []string
+		Healthcheck *struct {
+			Test     []string
+			Interval int64 // nanoseconds
+			Retries  int
+		}
+	}
+	State struct {
+		Status     string
+		OOMKilled  bool
+		ExitCode   int
+		Error      string
+		StartedAt  string
+		FinishedAt string
+		Health     *struct {
+			Status        string
+			FailingStreak int
+			Log           []Probe
+		}
+	}
+	Mounts []struct {
+		Type        string
+		Name        string
+		Source      string
+		Destination string
+		RW          bool
+	}
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostIp   string
+			HostPort string
+		}
+	}
+	HostConfig struct {
+		RestartPolicy struct {
+			Name              string
+			MaximumRetryCount int
+		}
+	}
+}
+
+// RestartPolicy is the restart policy as docker run takes it, e.g. "no" or "on-failure:3"
+func (c Container) RestartPolicy() string {
+	policy := c.HostConfig.RestartPolicy.Name
+	if policy == "" {
+		policy = "no"
+	}
+	if policy == "on-failure" && c.HostConfig.RestartPolicy.MaximumRetryCount > 0 {
+		policy = fmt.Sprintf("%s:%d", policy, c.HostConfig.RestartPolicy.MaximumRetryCount)
+	}
+	return policy
+}
+
+// Check is the health check command ("" when the container has none or it is disabled)
+func (c Container) Check() string {
+	hc := c.Config.Healthcheck
+	if hc == nil || len(hc.Test) == 0 || hc.Test[0] == "NONE" {
+		return ""
+	}
+	return strings.Join(hc.Test, " ")
+}
+
+// Took is how long the probe ran ("-" when docker did not record both times)
+func (p Probe) Took() string {
+	start, errStart := time.Parse(time.RFC3339Nano, p.Start)
+	end, errEnd := time.Parse(time.RFC3339Nano, p.End)
+	if errStart != nil || errEnd != nil {
+		return "-"
+	}
+	return end.Sub(start).Round(time.Millisecond).String()
+}
+
+// OutputLine is the probe output on one line
+func (p Probe) OutputLine() string {
+	return strings.Join(strings.Fields(p.Output), " ")
+}
+
+// HealthColor is the color name of a health check status (see Status.Color)
+func HealthColor(status string) string {
+	switch status {
+	case "healthy":
+		return "green"
+	case "unhealthy":
+		return "red"
+	}
+	return "yellow"
+}
+
+// FormatTime turns docker's RFC3339 timestamps into local time ("-" when unset)
+func FormatTime(value string) string {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || t.Year() <= 1 {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// Status summarizes the inspect data. The health of a stopped container is stale and left out.
+func (c Container) Status() Status {
+	st := Status{
+		State:        c.State.Status,
+		RestartCount: c.RestartCount,
+		OOMKilled:    c.State.OOMKilled,
+		ExitCode:     c.State.ExitCode,
+		Error:        c.State.Error,
+	}
+	if c.State.Health != nil && c.State.Status == "running" {
+		st.Health = c.State.Health.Status
+	}
+	return st
+}
+
+// Inspect runs one docker inspect for all the containers. Names that do not exist are
+// left out as long as at least one container was found.
+func Inspect(names ...string) ([]Container, error) {
+	return InspectContext(context.Background(), names...)
+}
+
+// InspectContext is Inspect with a context that can cancel the docker call
+func InspectContext(ctx context.Context, names ...string) ([]Container, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	out, err := exec.CommandContext(ctx, "docker", append([]string{"inspect", "--type", "container"}, names...)...).Output()
+	// docker inspect exits non-zero when any name is missing but still prints the others
+	var list []Container
+	if jsonErr := json.Unmarshal(out, &list); jsonErr != nil || len(list) == 0 {
+		if err == nil {
+			err = jsonErr
+		}
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			err = fmt.Errorf("%s", strings.TrimPrefix(strings.TrimSpace(string(ee.Stderr)), "Error: "))
+		}
+		if err == nil {
+			err = fmt.Errorf("no such container: %s", strings.Join(names, ", "))
+		}
+		return nil, err
+	}
+	for i := range list {
+		list[i].Name = strings.TrimPrefix(list[i].Name, "/")
+	}
+	return list, nil
+}
+
+// psExitCode and psHealth read the STATUS column of docker ps: "Up 2 hours (healthy)",
+// "Up 5 seconds (health: starting)", "Exited (137) 3 minutes ago", "Restarting (1) 10 seconds ago"
+var (
+	psExitCode = regexp.MustCompile(`^\w+ \((-?\d+)\)`)
+	psHealth   = regexp.MustCompile(`\((?:health: )?(starting|healthy|unhealthy)\)`)
+)
+
+// FromPS reads what it can from the STATUS column of docker ps. Restart counts, OOM kills
+// and errors are not in it; use Inspect for those.
+func FromPS(status string) Status {
+	status = strings.TrimSpace(status)
+	var st Status
+	word, _, _ := strings.Cut(status, " ")
+	switch strings.ToLower(word) {
+	case "up":
+		st.State = "running"
+		if strings.Contains(status, "(Paused)") {
+			st.State = "paused"
+		}
+	case "":
+		st.State = "unknown"
+	case "removal":
+		st.State = "removing"
+	default:
+		st.State = strings.ToLower(word)
+	}
+	if m := psExitCode.FindStringSubmatch(status); m != nil {
+		st.ExitCode, _ = strconv.Atoi(m[1])
+	}
+	if m := psHealth.FindStringSubmatch(status); m != nil {
+		st.Health = m[1]
+	}
+	return st
+}
+
+// NeedsInspect reports whether Inspect would add something the STATUS column of docker ps
+// leaves out: why a container stopped or failed to start (OOM kill, daemon error) and the
+// restart count of one that keeps restarting. Running containers are read from ps alone.
+func (s Status) NeedsInspect() bool {
+	switch s.State {
+	case "restarting", "dead", "created":
+		return true
+	case "exited":
+		return s.ExitCode != 0
+	}
+	return false
+}
+
+// signals names the signals behind the usual 128+n exit codes
+var signals = map[int]string{1: "SIGHUP", 2: "SIGINT", 6: "SIGABRT", 9: "SIGKILL", 11: "SIGSEGV", 13: "SIGPIPE", 15: "SIGTERM"}
+
+// stopped reports whether the exit code belongs to the last run rather than a running process
+func (s Status) stopped() bool {
+	return s.State == "exited" || s.State == "restarting" || s.State == "dead"
+}
+
+// ExitReason explains why the container stopped ("" while it runs or after a clean exit)
+func (s Status) ExitReason() string {
+	if !s.stopped() && s.State != "created" {
+		return ""
+	}
+	switch {
+	case s.OOMKilled:
+		return "OOM killed"
+	case s.Error != "":
+		return s.Error
+	}
+	switch code := s.ExitCode; {
+	case code == 0:
+		return ""
+	case code == 125:
+		return "docker run failed"
+	case code == 126:
+		return "command not executable"
+	case code == 127:
+		return "command not found"
+	case code > 128 && code <= 128+64:
+		if name, ok := signals[code-128]; ok {
+			return name
+		}
+		return fmt.Sprintf("signal %d", code-128)
+	}
+	return ""
+}
+
+// StateText is the state with the exit code of stopped containers, e.g. "exited(137)"
+func (s Status) StateText() string {
+	if s.stopped() {
+		return fmt.Sprintf("%s(%d)", s.State, s.ExitCode)
+	}
+	return s.State
+}
+
+// restarts is the restart count as text ("" when it never restarted)
+func (s Status) restarts() string {
+	switch s.RestartCount {
+	case 0:
+		return ""
+	case 1:
+		return "1 restart"
+	}
+	return fmt.Sprintf("%d restarts", s.RestartCount)
+}
+
+// Summary is the one-line status shown by ps and project ps,
+// e.g. "running, unhealthy, 3 restarts" or "exited(137), OOM killed"
+func (s Status) Summary() string {
+	parts := []string{s.StateText()}
+	for _, part := range []string{s.Health, s.ExitReason(), s.restarts()} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Badge is the short marker for the prompt: the health, or the state when the container
+// is not running, plus ↻N after restarts. It is empty for a running container with
+// nothing to report.
+func (s Status) Badge() string {
+	var parts []string
+	switch {
+	case s.State != "running" && s.State != "":
+		parts = append(parts, s.StateText())
+	case s.Health != "":
+		parts = append(parts, s.Health)
+	}
+	if s.OOMKilled && s.State != "running" {
+		parts = append(parts, "OOM")
+	}
+	if s.RestartCount > 0 {
+		parts = append(parts, fmt.Sprintf("↻%d", s.RestartCount))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Color is the color name of the status (names understood by themes.Colorize):
+// red for failures, yellow for transitions, clean stops and restarts, green for healthy running containers
+func (s Status) Color() string {
+	switch {
+	case s.Health == "unhealthy", s.OOMKilled && s.State != "running", s.State == "dead", s.State == "restarting":
+		return "red"
+	case s.State == "exited":
+		switch s.ExitCode {
+		case 0, 128 + 2, 128 + 15:
+			return "yellow"
+		}
+		return "red"
+	case s.Health == "starting", s.State == "paused", s.State == "removing", s.State == "running" && s.RestartCount > 0:
+		return "yellow"
+	case s.State == "running":
+		return "green"
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package health
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFromPS(t *testing.T) {
+	tests := []struct {
+		status string
+		want   Status
+	}{
+		{"Up 2 hours", Status{State: "running"}},
+		{"Up 2 hours (healthy)", Status{State: "running", Health: "healthy"}},
+		{"Up 5 seconds (health: starting)", Status{State: "running", Health: "starting"}},
+		{"Up 3 minutes (unhealthy)", Status{State: "running", Health: "unhealthy"}},
+		{"Up 1 hour (Paused)", Status{State: "paused"}},
+		{"Exited (0) 3 minutes ago", Status{State: "exited"}},
+		{"Exited (137) 3 minutes ago", Status{State: "exited", ExitCode: 137}},
+		{"Restarting (1) 10 seconds ago", Status{State: "restarting", ExitCode: 1}},
+		{"Created", Status{State: "created"}},
+		{"Dead", Status{State: "dead"}},
+		{"Removal In Progress", Status{State: "removing"}},
+		{"  ", Status{State: "unknown"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := FromPS(tt.status); got != tt.want {
+				t.Errorf("FromPS(%q) = %+v, want %+v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       Status
+		summary      string
+		reason       string
+		badge        string
+		color        string
+		needsInspect bool
+	}{
+		{"running", Status{State: "running"}, "running", "", "", "green", false},
+		{"healthy", Status{State: "running", Health: "healthy"}, "running, healthy", "", "healthy", "green", false},
+		{"starting", Status{State: "running", Health: "starting"}, "running, starting", "", "starting", "yellow", false},
+		{"unhealthy with restarts", Status{State: "running", Health: "unhealthy", RestartCount: 3}, "running, unhealthy, 3 restarts", "", "unhealthy ↻3", "red", false},
+		{"one restart", Status{State: "running", RestartCount: 1}, "running, 1 restart", "", "↻1", "yellow", false},
+		{"clean exit", Status{State: "exited"}, "exited(0)", "", "exited(0)", "yellow", false},
+		{"stopped by SIGTERM", Status{State: "exited", ExitCode: 143}, "exited(143), SIGTERM", "SIGTERM", "exited(143)", "yellow", true},
+		{"OOM killed", Status{State: "exited", ExitCode: 137, OOMKilled: true}, "exited(137), OOM killed", "OOM killed", "exited(137) OOM", "red", true},
+		{"killed", Status{State: "exited", ExitCode: 137}, "exited(137), SIGKILL", "SIGKILL", "exited(137)", "red", true},
+		{"unnamed signal", Status{State: "exited", ExitCode: 128 + 10}, "exited(138), signal 10", "signal 10", "exited(138)", "red", true},
+		{"command not found", Status{State: "exited", ExitCode: 127}, "exited(127), command not found", "command not found", "exited(127)", "red", true},
+		{"plain failure", Status{State: "exited", ExitCode: 1}, "exited(1)", "", "exited(1)", "red", true},
+		{"restarting", Status{State: "restarting", ExitCode: 1, RestartCount: 4}, "restarting(1), 4 restarts", "", "restarting(1) ↻4", "red", true},
+		{"created with an error", Status{State: "created", Error: "port is already allocated"}, "created, port is already allocated", "port is already allocated", "created", "", true},
+		{"dead", Status{State: "dead"}, "dead(0)", "", "dead(0)", "red", true},
+		{"paused", Status{State: "paused"}, "paused", "", "paused", "yellow", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Summary(); got != tt.summary {
+				t.Errorf("Summary() = %q, want %q", got, tt.summary)
+			}
+			if got := tt.status.ExitReason(); got != tt.reason {
+				t.Errorf("ExitReason() = %q, want %q", got, tt.reason)
+			}
+			if got := tt.status.Badge(); got != tt.badge {
+				t.Errorf("Badge() = %q, want %q", got, tt.badge)
+			}
+			if got := tt.status.Color(); got != tt.color {
+				t.Errorf("Color() = %q, want %q", got, tt.color)
+			}
+			if got := tt.status.NeedsInspect(); got != tt.needsInspect {
+				t.Errorf("NeedsInspect() = %v, want %v", got, tt.needsInspect)
+			}
+		})
+	}
+}
+
+func TestContainerStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		inspect string
+		want    Status
+		policy  string
+		check   string
+	}{
+		{
+			name: "running with a health check",
+			inspect: `{"RestartCount":2,"Config":{"Healthcheck":{"Test":["CMD-SHELL","curl -f localhost"]}},
+				"State":{"Status":"running","Health":{"Status":"unhealthy"}},
+				"HostConfig":{"RestartPolicy":{"Name":"on-failure","MaximumRetryCount":3}}}`,
+			want:   Status{State: "running", Health: "unhealthy", RestartCount: 2},
+			policy: "on-failure:3",
+			check:  "CMD-SHELL curl -f localhost",
+		},
+		{
+			name: "stale health of a stopped container is dropped",
+			inspect: `{"Config":{"Healthcheck":{"Test":["NONE"]}},
+				"State":{"Status":"exited","ExitCode":137,"OOMKilled":true,"Health":{"Status":"healthy"}},
+				"HostConfig":{"RestartPolicy":{"Name":"always"}}}`,
+			want:   Status{State: "exited", ExitCode: 137, OOMKilled: true},
+			policy: "always",
+		},
+		{
+			name:    "no restart policy",
+			inspect: `{"State":{"Status":"created","Error":"no such image"}}`,
+			want:    Status{State: "created", Error: "no such image"},
+			policy:  "no",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Container
+			if err := json.Unmarshal([]byte(tt.inspect), &c); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Status(); got != tt.want {
+				t.Errorf("Status() = %+v, want %+v", got, tt.want)
+			}
+			if got := c.RestartPolicy(); got != tt.policy {
+				t.Errorf("RestartPolicy() = %q, want %q", got, tt.policy)
+			}
+			if got := c.Check(); got != tt.check {
+				t.Errorf("Check() = %q, want %q", got, tt.check)
+			}
+		})
+	}
+}
+
+func TestProbe(t *testing.T) {
+	p := Probe{Start: "2026-01-01T00:00:00.000000001Z", End: "2026-01-01T00:00:00.250Z", Output: "  curl: (7)\n  Failed to connect\n"}
+	if got := p.Took(); got != "250ms" {
+		t.Errorf("Took() = %q, want 250ms", got)
+	}
+	if got := p.OutputLine(); got != "curl: (7) Failed to connect" {
+		t.Errorf("OutputLine() = %q", got)
+	}
+	if got := (Probe{Start: "bad"}).Took(); got != "-" {
+		t.Errorf("Took() without times = %q, want -", got)
+	}
+	if got := FormatTime("0001-01-01T00:00:00Z"); got != "-" {
+		t.Errorf("FormatTime(zero) = %q, want -", got)
+	}
+}
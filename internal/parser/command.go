@@ -9,6 +9,7 @@ type ParsedCommand struct {
 	Command   string
 	Args      []string
 	Options   map[string]string
+	RawArgs   []string // arguments in their original order, options included
 	IsDocker  bool
 	IsLinux   bool
 	IsBuiltin bool
@@ -114,6 +115,7 @@ func (parser *DefaultCommandParser) ParseCommand(input string) (*ParsedCommand,
 		Command:   command,
 		Args:      filteredArgs,
 		Options:   options,
+		RawArgs:   args,
 		IsDocker:  parser.IsDockerCommand(command),
 		IsLinux:   parser.IsLinuxCommand(command),
 		IsBuiltin: parser.IsBuiltinCommand(command),
@@ -214,7 +216,8 @@ func isFlagOption(option string) bool {
 		"q", "quiet", // for --quiet
 		"r", "recursive", // for -r
 		"i", "interactive", // for -i
-		"force",   // for --force
+		"force",    // for --force
+		"y", "yes", // for rm/rmi --yes
 		"dry-run", // for --dry-run
 	}
 
@@ -54,6 +54,31 @@ func main() {
 	// シェルを初期化
 	s := shell.NewShell(cfg, dataPath)
 
+	// シェル補完スクリプトの出力と、スクリプトから呼ばれる補完エントリポイント、
+	// --help-markdown はコマンドリファレンスを Markdown で出力
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "completion":
+			if len(os.Args) < 3 {
+				fmt.Println(i18n.T("completion.usage"))
+				os.Exit(1)
+			}
+			script, err := shell.CompletionScript(os.Args[2])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print(script)
+			return
+		case "__complete":
+			fmt.Print(shell.FormatCompletions(s.CompleteWords(os.Args[2:])))
+			return
+		case "--help-markdown":
+			fmt.Print(s.HelpMarkdown())
+			return
+		}
+	}
+
 	// コマンドライン引数が渡された場合は直接実行
 	if len(os.Args) > 1 {
 		// 引数を結合してコマンドとして実行
@@ -0,0 +1,419 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"docsh/i18n"
+	"docsh/internal/parser"
+)
+
+// registerBuiltinCommands は内蔵・ライフサイクル・マッピング対象コマンドをレジストリへ登録します。
+// 登録順はコマンド名補完の表示順になります。
+func registerBuiltinCommands(r *commandRegistry) {
+	specs := []*commandSpec{
+		{Name: "cd", Kind: kindBuiltin, Usage: "cd [dir|container:/path]", DescKey: "completion.descriptions.cd", Args: argDirectory,
+			Examples: []string{"cd web:/var/log", "cd nginx:/", "cd ..", "cd"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.runCd(c.Args) }},
+		{Name: "use", Kind: kindBuiltin, Usage: "use [<container|service>|-|--clear]", DescKey: "completion.descriptions.use",
+			Options:  []optionSpec{{Name: "--clear", Short: "-c", DescKey: "help.option_descriptions.clear_context"}},
+			Examples: []string{"use web", "logs -f", "use -", "use --clear"},
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				if len(positionalArgs(words[1:], nil)) > 1 || (len(words) > 1 && strings.HasSuffix(line, " ")) {
+					return []Suggest{}
+				}
+				return s.completeUseTargets(currentArg)
+			},
+			Handler: (*Shell).runUse},
+		{Name: "login", Kind: kindLifecycle, Usage: "login <container>", DescKey: "completion.descriptions.login", Args: argRunningContainer, Output: outputInteractive, Context: true,
+			Handler: (*Shell).runLogin},
+		{Name: "pwd", Kind: kindBuiltin, Usage: "pwd", DescKey: "completion.descriptions.pwd",
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { fmt.Println(s.getCurrentDir()); return nil }},
+		{Name: "ls", Kind: kindMapping, Usage: "ls [-a]", DescKey: "completion.descriptions.ls", Args: argPath, Output: outputCaptured,
+			Options: []optionSpec{{Short: "-a", DescKey: "help.option_descriptions.all"}},
+			Handler: (*Shell).runMappedCommand},
+		{Name: "cat", Kind: kindMapping, Usage: "cat <file>", DescKey: "completion.descriptions.cat", Args: argContainerPath, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "cp", Kind: kindMapping, Usage: "cp [-r] [-p] <src>... <dest>", DescKey: "completion.descriptions.cp", Args: argContainerPath, Safety: safetyMutating,
+			Options:  transferOptions,
+			Examples: []string{"cp ./nginx.conf web:/etc/nginx/", "cp -r web:/var/log ./logs", "cp web:/var/log/*.log ./logs/", "cp -rp web:/data db:/backup"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.runTransfer(c, false) }},
+		{Name: "mv", Kind: kindMapping, Usage: "mv [-p] <src>... <dest> | mv <container> <src> <dest>", DescKey: "completion.descriptions.mv", Safety: safetyMutating,
+			Options:  transferOptions[1:],
+			Examples: []string{"mv web:/tmp/dump.sql ./", "mv ./app.conf web:/etc/app/", "mv web /tmp/a.txt /tmp/b.txt"},
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				// 従来形式（mv <container> <src> <dest>）では第2引数以降をそのコンテナ内のパスとして補完
+				args := positionalArgs(words[1:], transferOptions)
+				if len(args) >= 2 || (len(args) == 1 && strings.HasSuffix(line, " ")) {
+					if _, _, ok := splitContainerPath(args[0]); !ok && strings.HasPrefix(currentArg, "/") && !hostPathExists(args[0]) {
+						return s.completeContainerFiles(args[0], currentArg)
+					}
+				}
+				return s.completeHostOrContainerPath(currentArg)
+			},
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.runTransfer(c, true) }},
+		{Name: "mkdir", Kind: kindMapping, Usage: "mkdir <container> <dir>", DescKey: "completion.descriptions.mkdir", Args: argRunningContainer, Safety: safetyMutating, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "rmdir", Kind: kindMapping, Usage: "rmdir <dir>", DescKey: "completion.descriptions.rmdir", Args: argPath, Safety: safetyDestructive, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "touch", Kind: kindMapping, Usage: "touch <file>", DescKey: "completion.descriptions.touch", Args: argPath, Safety: safetyMutating, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "echo", Kind: kindMapping, Usage: "echo <text>", DescKey: "completion.descriptions.echo", Args: argPath, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "clear", Kind: kindBuiltin, Usage: "clear", DescKey: "completion.descriptions.clear",
+			Handler: (*Shell).runClear},
+		{Name: "cls", Kind: kindBuiltin, Usage: "cls", DescKey: "completion.descriptions.clear", Hidden: true,
+			Handler: (*Shell).runClear},
+		{Name: "exit", Kind: kindBuiltin, Usage: "exit", DescKey: "completion.descriptions.exit",
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return nil }},
+		{Name: "quit", Kind: kindBuiltin, Usage: "quit", DescKey: "completion.descriptions.exit", Hidden: true,
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return nil }},
+		{Name: "ps", Kind: kindMapping, Usage: "ps [-a] [--by-project]", DescKey: "completion.descriptions.ps", Output: outputCaptured,
+			Options: []optionSpec{{Short: "-a", DescKey: "help.option_descriptions.all"}, {Name: "--by-project", DescKey: "help.option_descriptions.by_project"}},
+			Handler: (*Shell).runPs},
+		{Name: "kill", Kind: kindMapping, Usage: "kill [-9] <container>", DescKey: "completion.descriptions.kill", Args: argRunningContainer, Safety: safetyMutating, Output: outputCaptured, Context: true,
+			Options: []optionSpec{{Short: "-9", DescKey: "help.option_descriptions.kill_signal"}},
+			Handler: (*Shell).runMappedCommand},
+		{Name: "rm", Kind: kindLifecycle, Usage: "rm [--force] [--yes] <container|selector>...", DescKey: "completion.descriptions.rm", Args: argContainer, Safety: safetyDestructive, Output: outputInteractive,
+			Options:  []optionSpec{forceOption, yesOption, labelOption},
+			Examples: []string{"rm web", "rm status=exited", "rm -f -y --label env=dev"},
+			Handler:  (*Shell).runRemoveContainer},
+		{Name: "rmi", Kind: kindLifecycle, Usage: "rmi [--force] [--yes] <image|selector>...", DescKey: "completion.descriptions.rmi", Args: argImage, Safety: safetyDestructive, Output: outputInteractive,
+			Options:  []optionSpec{forceOption, yesOption, labelOption},
+			Examples: []string{"rmi nginx:1.25", "rmi myapp:*", "rmi -f --label stage=build"},
+			Handler:  (*Shell).runRemoveImage},
+		{Name: "start", Kind: kindLifecycle, Usage: "start <container|selector>...", DescKey: "completion.descriptions.start", Args: argContainer, Safety: safetyMutating, Context: true,
+			Options:  []optionSpec{labelOption},
+			Examples: []string{"start web", "start project=shop", "start status=exited image=nginx*"},
+			Handler:  (*Shell).runStart},
+		{Name: "stop", Kind: kindLifecycle, Usage: "stop <container|selector>...", DescKey: "completion.descriptions.stop", Args: argRunningContainer, Safety: safetyMutating, Context: true,
+			Options:  []optionSpec{labelOption},
+			Examples: []string{"stop web", "stop web-*", "stop /^api-[0-9]+$/", "stop --label env=dev"},
+			Handler:  (*Shell).runStop},
+		{Name: "exec", Kind: kindLifecycle, Usage: "exec <container> <cmd>", DescKey: "completion.descriptions.exec", Args: argRunningContainer, Safety: safetyMutating, Output: outputInteractive, Context: true,
+			Examples: []string{"exec web ls /app"},
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				return s.completeExecArguments(words[1:], currentArg, strings.HasSuffix(line, " "))
+			},
+			Handler: (*Shell).runExec},
+		{Name: "logs", Kind: kindLifecycle, Usage: "logs [-f] [--tail N] [container|selector...] | logs search <pattern> [selector...]", DescKey: "completion.descriptions.logs", Args: argContainer, Context: true,
+			Options:  containerLogsOptions,
+			Examples: []string{"logs web", "logs -f --tail 100", "logs --since 10m project=shop", "logs search req-1234 --since 1h", "logs search level=error --project shop -C 2"},
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				return s.completeLogsArguments(words, currentArg, line)
+			},
+			Handler: (*Shell).runLogs},
+		{Name: "inspect", Kind: kindLifecycle, Usage: "inspect [--format TEMPLATE] [container]", DescKey: "completion.descriptions.inspect", Args: argContainer, Context: true,
+			Options: []optionSpec{{Name: "--format", Short: "-f", Type: valueString, DescKey: "help.option_descriptions.format"}},
+			Handler: (*Shell).runInspect},
+		{Name: "health", Kind: kindBuiltin, Usage: "health [container]", DescKey: "completion.descriptions.health", Args: argContainer, Output: outputCaptured, Context: true,
+			Examples: []string{"health web-1", "health"},
+			Handler:  (*Shell).runHealth},
+		{Name: "pull", Kind: kindLifecycle, Usage: "pull <image>", DescKey: "completion.descriptions.pull", Safety: safetyMutating,
+			Handler: (*Shell).runPull},
+		{Name: "tail", Kind: kindMapping, Usage: "tail [-f] [-n N] <container>", DescKey: "completion.descriptions.tail", Args: argContainer, Output: outputCaptured, Context: true,
+			Options: []optionSpec{{Short: "-f", DescKey: "help.option_descriptions.follow"}, {Name: "--lines", Short: "-n", Type: valueInt, DescKey: "help.option_descriptions.lines"}},
+			Handler: (*Shell).runMappedCommand},
+		{Name: "head", Kind: kindMapping, Usage: "head [-n N] <container>", DescKey: "completion.descriptions.head", Args: argContainer, Output: outputCaptured, Context: true,
+			Options: []optionSpec{{Name: "--lines", Short: "-n", Type: valueInt, DescKey: "help.option_descriptions.lines"}},
+			Handler: (*Shell).runMappedCommand},
+		{Name: "grep", Kind: kindMapping, Usage: "grep <container>", DescKey: "completion.descriptions.grep", Args: argContainer, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "vi", Kind: kindMapping, Usage: "vi <container> <file>", DescKey: "completion.descriptions.vi", Args: argRunningContainer, Safety: safetyMutating, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "nano", Kind: kindMapping, Usage: "nano <container> <file>", DescKey: "completion.descriptions.nano", Args: argRunningContainer, Safety: safetyMutating, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "find", Kind: kindMapping, Usage: "find <container> <path>", DescKey: "completion.descriptions.find", Args: argRunningContainer, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "locate", Kind: kindMapping, Usage: "locate <container> <name>", DescKey: "completion.descriptions.locate", Args: argRunningContainer, Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "netstat", Kind: kindMapping, Usage: "netstat <container>", DescKey: "completion.descriptions.netstat", Args: argContainer, Output: outputCaptured, Context: true,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "free", Kind: kindMapping, Usage: "free", DescKey: "completion.descriptions.free", Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "top", Kind: kindMapping, Usage: "top [container]", DescKey: "completion.descriptions.top", Args: argRunningContainer, Output: outputStreaming, Context: true,
+			Handler: (*Shell).runTop},
+		{Name: "htop", Kind: kindBuiltin, Usage: "htop [--interval <duration>] [--retention <duration>] [--source auto|engine|stream|poll] [--record <file>] [--replay <file> [--speed <n>]]", DescKey: "completion.descriptions.htop", Options: htopOptions, Output: outputInteractive,
+			Examples: []string{"htop", "htop --interval 1s --retention 10m", "htop --interval 5s --retention 1h", "htop --source stream", "htop --record incident.jsonl", "htop --replay incident.jsonl --speed 4"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.launchContainerMonitor(c.RawArgs) }},
+		{Name: "watch", Kind: kindBuiltin, Usage: "watch [--interval <duration>] [--retention <duration>] [--source auto|engine|stream|poll]", DescKey: "completion.descriptions.watch", Options: watchOptions, Output: outputStreaming,
+			Examples: []string{"watch", "watch --interval 5s"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.runAlertWatch(c.RawArgs) }},
+		{Name: "events", Kind: kindBuiltin, Usage: "events [container...] [--type <type>] [--container <container>] [--project <project>] [--since <duration>] [--until <duration>] [--all]", DescKey: "completion.descriptions.events", Args: argContainer, Options: eventsOptions, Output: outputStreaming,
+			Examples: []string{"events", "events --since 30m", "events --project shop", "events web-1 --since 1h", "events --type network --type volume", "events --since 10m --until 0s --all"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.runEvents(c.RawArgs) }},
+		{Name: "metrics", Kind: kindBuiltin, Usage: "metrics serve [--listen <host:port>] [--source auto|engine|stream|poll]", DescKey: "completion.descriptions.metrics", Options: metricsOptions, Output: outputStreaming,
+			Examples: []string{"metrics serve", "metrics serve --listen 127.0.0.1:9339", "metrics serve --source poll"},
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				return s.completeMetricsCommand(words, currentArg, line)
+			},
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.handleMetricsCommand(c.RawArgs) }},
+		{Name: "df", Kind: kindMapping, Usage: "df", DescKey: "completion.descriptions.df", Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "du", Kind: kindMapping, Usage: "du", DescKey: "completion.descriptions.du", Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "uname", Kind: kindMapping, Usage: "uname", DescKey: "completion.descriptions.uname", Output: outputCaptured,
+			Handler: (*Shell).runMappedCommand},
+		{Name: "docker", Kind: kindBuiltin, Usage: "docker <subcommand> [args]", DescKey: "completion.descriptions.docker", Output: outputCaptured,
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				return s.completeDockerCommand(words, currentArg, line)
+			},
+			Handler: (*Shell).runMappedCommand},
+		{Name: "theme", Kind: kindBuiltin, Usage: "theme [name]", DescKey: "completion.descriptions.theme", Args: argTheme,
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.handleThemeCommand(c.Args) }},
+		{Name: "lang", Kind: kindBuiltin, Usage: "lang [language]", DescKey: "completion.descriptions.lang", Args: argLanguage,
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.handleLangCommand(c.Args) }},
+		{Name: "alias", Kind: kindBuiltin, Usage: "alias [name=command]", DescKey: "completion.descriptions.alias",
+			Examples: []string{"alias", "alias ll=ls -a"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.handleAliasCommand(c.Args) }},
+		{Name: "unalias", Kind: kindBuiltin, Usage: "unalias <name>", DescKey: "completion.descriptions.unalias",
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.handleUnaliasCommand(c.Args) }},
+		{Name: "config", Kind: kindBuiltin, Usage: "config [show]", DescKey: "completion.descriptions.config",
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.handleConfigCommand(c.Args) }},
+		{Name: "project", Kind: kindBuiltin, Usage: "project <project> [ps|logs|start|restart|stop|up|down|build|pull|config|graph] [service]", DescKey: "completion.descriptions.project",
+			Examples: []string{"project ps", "project myapp ps", "project myapp logs web", "project myapp restart web", "project myapp up", "project myapp build web", "project myapp down", "project myapp graph", "project myapp graph --dot"},
+			Complete: func(s *Shell, words []string, currentArg, line string) []Suggest {
+				return s.completeProjectCommand(words, currentArg, line)
+			},
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.handleProjectCommand(c.RawArgs) }},
+		{Name: "mapping", Kind: kindBuiltin, Usage: "mapping [list|search|show] <args>", DescKey: "completion.descriptions.mapping",
+			Examples: []string{"mapping list", "mapping list process-management", "mapping search logs", "mapping show tail -f"},
+			Handler:  func(s *Shell, c *parser.ParsedCommand) error { return s.handleMappingCommand(c.Args) }},
+		{Name: "search", Kind: kindBuiltin, Usage: "search <keyword>", DescKey: "completion.descriptions.mapping", Hidden: true,
+			Handler: func(s *Shell, c *parser.ParsedCommand) error {
+				return s.handleMappingCommand(append([]string{"search"}, c.Args...))
+			}},
+		{Name: "list", Kind: kindBuiltin, Usage: "list [category]", DescKey: "completion.descriptions.mapping", Hidden: true,
+			Handler: func(s *Shell, c *parser.ParsedCommand) error {
+				return s.handleMappingCommand(append([]string{"list"}, c.Args...))
+			}},
+		{Name: "help", Kind: kindBuiltin, Usage: "help [command]", DescKey: "completion.descriptions.help", Args: argCommand,
+			Handler: func(s *Shell, c *parser.ParsedCommand) error { return s.showHelp(c.Args) }},
+		{Name: "version", Kind: kindBuiltin, Usage: "version", DescKey: "completion.descriptions.version",
+			Handler: func(s *Shell, c *parser.ParsedCommand) error {
+				fmt.Println(i18n.T("app.docker_only_version"))
+				return nil
+			}},
+	}
+	for _, spec := range specs {
+		r.register(spec)
+	}
+}
+
+// runMappedCommand はマッピング経由（または docker 直接）でコマンドを実行し結果を表示します
+func (s *Shell) runMappedCommand(parsedCmd *parser.ParsedCommand) error {
+	// ストリーミングコマンドの場合は特別な処理を行う
+	if isStreamingCommand(parsedCmd) {
+		return s.executeStreamingCommandDirectly(parsedCmd)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := s.shellExecutor.Execute(ctx, parsedCmd)
+	if err != nil && parsedCmd.IsBuiltin {
+		return err
+	}
+	if err != nil {
+		// Docker専用シェルのエラーメッセージを表示
+		fmt.Printf("❌ %s\n", result.Error)
+		fmt.Println(i18n.T("app.docker_only_available_commands"))
+		fmt.Println(i18n.T("app.docker_only_commands_list"))
+		fmt.Println(i18n.T("app.docker_only_mapping_help"))
+		return nil
+	}
+
+	// 結果を表示
+	if result.Output != "" {
+		fmt.Print(result.Output)
+	}
+
+	// マッピング情報を表示
+	if result.Mapping != nil {
+		fmt.Printf("✅ %s -> %s\n", result.Mapping.LinuxCommand, result.Mapping.DockerCommand)
+	}
+	return nil
+}
+
+// runPs は ps --by-project を処理し、それ以外はマッピングに委ねます
+func (s *Shell) runPs(parsedCmd *parser.ParsedCommand) error {
+	if parsedCmd.Options["by-project"] == "true" {
+		return s.psByProject()
+	}
+	return s.runMappedCommand(parsedCmd)
+}
+
+func (s *Shell) runLogin(parsedCmd *parser.ParsedCommand) error {
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	return s.enterContainer(parsedCmd.Args[0])
+}
+
+func (s *Shell) runClear(parsedCmd *parser.ParsedCommand) error {
+	fmt.Print("\033[2J\033[H")
+	return nil
+}
+
+func (s *Shell) runPull(parsedCmd *parser.ParsedCommand) error {
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.image_name_required"))
+	}
+	return s.pullImage(parsedCmd.Args[0])
+}
+
+func (s *Shell) runStart(parsedCmd *parser.ParsedCommand) error {
+	if wantsTargets(parsedCmd.RawArgs, []optionSpec{labelOption}) {
+		return s.runTargetCommand(parsedCmd, []optionSpec{labelOption}, targetOperation{
+			Verb: "start",
+			Args: func(t dockerTarget) []string { return []string{"start", t.Name} },
+			Skip: func(t dockerTarget) string {
+				if t.State == "running" {
+					return i18n.T("selector.skip_running")
+				}
+				return ""
+			},
+		})
+	}
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	return s.startContainer(parsedCmd.Args[0])
+}
+
+func (s *Shell) runStop(parsedCmd *parser.ParsedCommand) error {
+	if wantsTargets(parsedCmd.RawArgs, []optionSpec{labelOption}) {
+		return s.runTargetCommand(parsedCmd, []optionSpec{labelOption}, targetOperation{
+			Verb: "stop",
+			Args: func(t dockerTarget) []string { return []string{"stop", t.Name} },
+			Skip: func(t dockerTarget) string {
+				if t.State != "running" {
+					return i18n.T("selector.skip_not_running")
+				}
+				return ""
+			},
+		})
+	}
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	return s.stopContainer(parsedCmd.Args[0])
+}
+
+func (s *Shell) runExec(parsedCmd *parser.ParsedCommand) error {
+	if len(parsedCmd.Args) < 2 {
+		return fmt.Errorf(i18n.T("docker.container_name_required") + " and " + i18n.T("docker.command_required"))
+	}
+	return s.execInContainer(parsedCmd.Args[0], parsedCmd.Args[1:])
+}
+
+// runLogs は docker logs を実行します（-f の場合はストリーミング）
+func (s *Shell) runLogs(parsedCmd *parser.ParsedCommand) error {
+	if len(parsedCmd.RawArgs) > 0 && parsedCmd.RawArgs[0] == "search" {
+		return s.runLogSearch(parsedCmd)
+	}
+	if wantsTargets(parsedCmd.RawArgs, containerLogsOptions) {
+		return s.runContainerLogs(parsedCmd)
+	}
+	if len(positionalArgs(parsedCmd.RawArgs, containerLogsOptions)) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	dockerCmd := append([]string{"docker", "logs"}, parsedCmd.RawArgs...)
+	if parsedCmd.Options["f"] == "true" || parsedCmd.Options["follow"] == "true" {
+		return s.streamDockerCommand(dockerCmd, nil)
+	}
+	return s.execDocker("logs", parsedCmd.RawArgs...)
+}
+
+func (s *Shell) runInspect(parsedCmd *parser.ParsedCommand) error {
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	return s.execDocker("inspect", parsedCmd.RawArgs...)
+}
+
+// runTop は docker stats を表示します（コンテナ指定時はそのコンテナのみ）
+func (s *Shell) runTop(parsedCmd *parser.ParsedCommand) error {
+	return s.streamDockerCommand(append([]string{"docker", "stats"}, parsedCmd.Args...), nil)
+}
+
+func (s *Shell) runRemoveContainer(parsedCmd *parser.ParsedCommand) error {
+	if options := []optionSpec{forceOption, yesOption, labelOption}; wantsTargets(parsedCmd.RawArgs, options) {
+		return s.runTargetCommand(parsedCmd, options, targetOperation{
+			Verb:    "rm",
+			Args:    func(t dockerTarget) []string { return removeArgs("rm", t.Name, hasForceOption(parsedCmd)) },
+			Confirm: true,
+		})
+	}
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	return s.removeContainer(parsedCmd.Args[0], hasForceOption(parsedCmd))
+}
+
+func (s *Shell) runRemoveImage(parsedCmd *parser.ParsedCommand) error {
+	if options := []optionSpec{forceOption, yesOption, labelOption}; wantsTargets(parsedCmd.RawArgs, options) {
+		return s.runTargetCommand(parsedCmd, options, targetOperation{
+			Verb:    "rmi",
+			Args:    func(t dockerTarget) []string { return removeArgs("rmi", t.Name, hasForceOption(parsedCmd)) },
+			Images:  true,
+			Confirm: true,
+		})
+	}
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.image_name_required"))
+	}
+	return s.removeImage(parsedCmd.Args[0], hasForceOption(parsedCmd))
+}
+
+// forceOption は rm / rmi の --force です
+var forceOption = optionSpec{Name: "--force", Short: "-f", DescKey: "help.option_descriptions.force"}
+
+// yesOption は複数の対象を削除する前の確認を省略します（スクリプト向け）
+var yesOption = optionSpec{Name: "--yes", Short: "-y", DescKey: "help.option_descriptions.yes"}
+
+// removeArgs は docker rm / rmi の引数を組み立てます
+func removeArgs(verb, name string, force bool) []string {
+	if force {
+		return []string{verb, "-f", name}
+	}
+	return []string{verb, name}
+}
+
+// hasForceOption は --force / -f（-rf などの結合形式を含む）が指定されたかを返します
+func hasForceOption(parsedCmd *parser.ParsedCommand) bool {
+	return parsedCmd.Options["force"] == "true" || parsedCmd.Options["f"] == "true"
+}
+
+func (s *Shell) handleConfigCommand(args []string) error {
+	if len(args) == 0 {
+		fmt.Println(i18n.T("config.usage"))
+		return nil
+	}
+	switch args[0] {
+	case "show":
+		s.showConfig()
+	default:
+		fmt.Printf(i18n.T("config.unknown_command")+"\n", args[0])
+	}
+	return nil
+}
+
+// isInteractiveCommand は入力行が端末を占有するコマンドか（エイリアス展開後も含めて）判定します
+func (s *Shell) isInteractiveCommand(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	spec, ok := s.registry.resolve(fields[0])
+	if ok && spec.Kind == kindAlias {
+		expanded := strings.Fields(spec.AliasOf)
+		if len(expanded) == 0 {
+			return false
+		}
+		spec, ok = s.registry.lookup(expanded[0])
+	}
+	return ok && spec.Output == outputInteractive
+}
@@ -0,0 +1,259 @@
+package shell
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"docsh/i18n"
+	"docsh/internal/parser"
+)
+
+// maxConcurrentTargets は複数の対象を同時に操作する上限です
+const maxConcurrentTargets = 8
+
+// targetResult は1対象分の実行結果です
+type targetResult struct {
+	Target  dockerTarget
+	Output  string
+	Err     error
+	Skipped string // スキップした理由（実行した場合は空）
+}
+
+// targetOperation は複数対象に対する1種類の操作です
+type targetOperation struct {
+	Verb    string                        // 表示用の操作名（stop, rm など）
+	Args    func(t dockerTarget) []string // docker に渡す引数
+	Skip    func(t dockerTarget) string   // 実行不要ならその理由を返す
+	Images  bool                          // 対象がイメージか
+	Confirm bool                          // 複数の対象なら実行前に y/N で確認するか（rm, rmi）
+}
+
+// runTargetCommand は選択子を解決し、対象を表示してから操作を並行実行します。
+// 取り消せない操作で対象が複数のときは、--yes がなければ実行前に確認します
+func (s *Shell) runTargetCommand(parsedCmd *parser.ParsedCommand, options []optionSpec, op targetOperation) error {
+	selectors, err := parseTargetSelectors(parsedCmd.RawArgs, options)
+	if err != nil {
+		return err
+	}
+	targets, err := s.resolveTargets(selectors, op.Images)
+	if err != nil {
+		return err
+	}
+	printTargets(op.Verb, targets, op.Images)
+	if op.Confirm && len(targets) > 1 && !hasYesOption(parsedCmd) && !confirmTargets(op.Verb, len(targets)) {
+		return fmt.Errorf(i18n.T("selector.aborted"))
+	}
+
+	var succeeded, failed, skipped int
+	runOnTargets(targets, func(t dockerTarget) targetResult {
+		if op.Skip != nil {
+			if reason := op.Skip(t); reason != "" {
+				return targetResult{Target: t, Skipped: reason}
+			}
+		}
+		out, err := exec.Command("docker", op.Args(t)...).CombinedOutput()
+		return targetResult{Target: t, Output: strings.TrimSpace(string(out)), Err: err}
+	}, func(r targetResult) {
+		switch {
+		case r.Skipped != "":
+			skipped++
+			fmt.Printf("  ⏭️  %s: %s\n", r.Target.Name, r.Skipped)
+		case r.Err != nil:
+			failed++
+			fmt.Printf("  ❌ %s: %s\n", r.Target.Name, firstLine(r.Output, r.Err))
+		default:
+			succeeded++
+			fmt.Printf("  ✅ %s\n", r.Target.Name)
+		}
+	})
+
+	fmt.Printf(i18n.T("selector.summary")+"\n", succeeded, failed, skipped)
+	if failed > 0 {
+		return fmt.Errorf(i18n.T("selector.some_failed"), failed, len(targets))
+	}
+	return nil
+}
+
+// resolveTargets は選択子をコンテナまたはイメージの一覧に解決します（一致なしはエラー）
+func (s *Shell) resolveTargets(selectors []targetSelector, images bool) ([]dockerTarget, error) {
+	var (
+		targets []dockerTarget
+		err     error
+	)
+	if images {
+		targets, err = s.resolveImageTargets(selectors)
+	} else {
+		targets, err = s.resolveContainerTargets(selectors)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf(i18n.T("selector.no_match"))
+	}
+	return targets, nil
+}
+
+// printTargets は実行前に対象の一覧を表示します
+func printTargets(verb string, targets []dockerTarget, images bool) {
+	fmt.Printf(i18n.T("selector.targets")+"\n", verb, len(targets))
+	width := 0
+	for _, t := range targets {
+		if len(t.Name) > width {
+			width = len(t.Name)
+		}
+	}
+	for _, t := range targets {
+		if images {
+			fmt.Printf("  %-*s  %s\n", width, t.Name, t.ID)
+		} else {
+			fmt.Printf("  %-*s  %-10s %s\n", width, t.Name, t.State, t.Image)
+		}
+	}
+}
+
+// confirmTargets は y/N を尋ね、y または yes が入力された場合だけ true を返します。
+// 入力がない（EOF の）場合は実行しません
+func confirmTargets(verb string, count int) bool {
+	fmt.Printf(i18n.T("selector.confirm"), verb, count)
+	// 後続の入力を読み込みすぎないよう1バイトずつ読む
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if n == 0 || err != nil {
+			fmt.Println()
+			break
+		}
+		if buf[0] == '\n' || buf[0] == '\r' {
+			break
+		}
+		line = append(line, buf[0])
+	}
+	switch strings.ToLower(strings.TrimSpace(string(line))) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// hasYesOption は確認を省略する --yes / -y が指定されたか判定します
+func hasYesOption(parsedCmd *parser.ParsedCommand) bool {
+	return parsedCmd.Options["yes"] == "true" || parsedCmd.Options["y"] == "true"
+}
+
+// runOnTargets は各対象に run を並行実行します。report は完了した順に1件ずつ呼ばれます。
+// 結果は対象と同じ順序で返します。
+func runOnTargets(targets []dockerTarget, run func(t dockerTarget) targetResult, report func(r targetResult)) []targetResult {
+	results := make([]targetResult, len(targets))
+	sem := make(chan struct{}, maxConcurrentTargets)
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t dockerTarget) {
+			defer wg.Done()
+			sem <- struct{}{}
+			r := run(t)
+			<-sem
+			mu.Lock()
+			results[i] = r
+			if report != nil {
+				report(r)
+			}
+			mu.Unlock()
+		}(i, t)
+	}
+	wg.Wait()
+	return results
+}
+
+// firstLine は docker の出力（無ければエラー）の最初の行を返します
+func firstLine(output string, err error) string {
+	if output == "" {
+		return err.Error()
+	}
+	if i := strings.IndexByte(output, '\n'); i >= 0 {
+		return output[:i]
+	}
+	return output
+}
+
+// optionTokens は選択子を除いたオプション部分（値を取るオプションはその値も含む）を返します
+func optionTokens(raw []string, options []optionSpec) []string {
+	var tokens []string
+	for i := 0; i < len(raw); i++ {
+		arg := raw[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			continue
+		}
+		takesValue := false
+		if opt, ok := findOption(options, arg); ok && opt.Type != valueNone && !strings.Contains(arg, "=") {
+			takesValue = i+1 < len(raw)
+		}
+		if arg == "--label" || strings.HasPrefix(arg, "--label=") {
+			if takesValue {
+				i++
+			}
+			continue
+		}
+		tokens = append(tokens, arg)
+		if takesValue {
+			i++
+			tokens = append(tokens, raw[i])
+		}
+	}
+	return tokens
+}
+
+// containerLogsOptions は logs が受け付けるオプションです（docker logs のオプションと --label）
+var containerLogsOptions = append(append([]optionSpec{}, dockerLogsOptions...), labelOption)
+
+// runContainerLogs は複数コンテナのログを並行して取得し、コンテナごとにまとめて表示します。
+// -f の場合は全コンテナを追跡し、コンテナ名を付けて1つにまとめて表示します。
+func (s *Shell) runContainerLogs(parsedCmd *parser.ParsedCommand) error {
+	selectors, err := parseTargetSelectors(parsedCmd.RawArgs, containerLogsOptions)
+	if err != nil {
+		return err
+	}
+	targets, err := s.resolveTargets(selectors, false)
+	if err != nil {
+		return err
+	}
+	printTargets("logs", targets, false)
+
+	if parsedCmd.Options["f"] == "true" || parsedCmd.Options["follow"] == "true" {
+		muxOpts, _, err := parseMuxLogOptions(parsedCmd.RawArgs, containerLogsOptions)
+		if err != nil {
+			return err
+		}
+		var sources []logSource
+		for i, t := range targets {
+			sources = append(sources, logSource{Label: t.Name, Container: t.Name, Color: i})
+		}
+		return s.runMultiplexedLogs(sources, muxOpts)
+	}
+
+	opts := optionTokens(parsedCmd.RawArgs, containerLogsOptions)
+	results := runOnTargets(targets, func(t dockerTarget) targetResult {
+		args := append(append([]string{"logs"}, opts...), t.Name)
+		out, err := exec.Command("docker", args...).CombinedOutput()
+		return targetResult{Target: t, Output: strings.TrimRight(string(out), "\n"), Err: err}
+	}, nil)
+	for _, r := range results {
+		fmt.Printf("\n==> %s <==\n", r.Target.Name)
+		if r.Err != nil {
+			fmt.Printf("❌ %s\n", firstLine(r.Output, r.Err))
+			continue
+		}
+		if r.Output != "" {
+			fmt.Println(r.Output)
+		}
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package shell
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -13,6 +12,8 @@ import (
 type Suggest struct {
 	Text        string
 	Description string
+	Preview     []string // 補完メニュー横のプレビュー欄に表示する行（無ければ非表示）
+	Matches     []int    // fuzzy マッチで一致した Text の位置（rune 単位、強調表示用）
 }
 
 // filterHasPrefix は前方一致でフィルタリングします（case-sensitive, leading match）
@@ -58,7 +59,6 @@ func (s *Shell) Complete(line string) []Suggest {
 		return s.completeCommands(words[0])
 	}
 
-	command := words[0]
 	var currentArg string
 	if strings.HasSuffix(beforeCursor, " ") {
 		currentArg = ""
@@ -66,115 +66,99 @@ func (s *Shell) Complete(line string) []Suggest {
 		currentArg = words[len(words)-1]
 	}
 
-	switch command {
-	case "cd":
-		return s.completeDirectories(currentArg)
-	case "login":
-		return s.completeDockerContainers(currentArg, true)
-	case "cat", "cp", "mv":
-		return s.completeFiles(currentArg)
-	case "rm":
-		return s.completeDockerContainers(currentArg, false)
-	case "rmi":
-		return s.completeDockerImages(currentArg)
-	case "start":
-		return s.completeDockerContainers(currentArg, false)
-	case "stop":
-		return s.completeDockerContainers(currentArg, true)
-	case "exec":
-		return s.completeDockerContainers(currentArg, true)
-	case "pull":
-		return []Suggest{}
-	case "ps":
-		return []Suggest{}
-	case "ls":
+	return s.completeArguments(words, currentArg, beforeCursor, 0)
+}
+
+// completeArguments はレジストリのメタデータに従って引数を補完します
+func (s *Shell) completeArguments(words []string, currentArg, beforeCursor string, depth int) []Suggest {
+	spec, ok := s.registry.resolve(words[0])
+	if !ok {
 		return s.completeFilesAndDirectories(currentArg)
-	case "kill":
-		return s.completeDockerContainers(currentArg, true)
-	case "tail", "head", "grep":
-		return s.completeDockerContainers(currentArg, false)
-	case "log":
-		// エイリアス: log -> docker logs [-f]
-		// 停止中も含め全コンテナから補完
+	}
+
+	if spec.Kind == kindAlias {
+		// エイリアスは展開先のコマンドとして補完（循環定義は深さで打ち切り）
+		expanded := strings.Fields(spec.AliasOf)
+		if len(expanded) == 0 || depth > 4 {
+			return []Suggest{}
+		}
+		expanded = append(expanded, words[1:]...)
+		if expanded[0] == spec.Name {
+			if spec, ok = s.registry.lookup(spec.Name); !ok {
+				return s.completeFilesAndDirectories(currentArg)
+			}
+			return s.completeSpecArguments(spec, expanded, currentArg, beforeCursor)
+		}
+		line := strings.Join(expanded, " ")
+		if strings.HasSuffix(beforeCursor, " ") {
+			line += " "
+		}
+		return s.completeArguments(expanded, currentArg, line, depth+1)
+	}
+
+	return s.completeSpecArguments(spec, words, currentArg, beforeCursor)
+}
+
+// completeSpecArguments は1コマンド分の引数補完を行います
+func (s *Shell) completeSpecArguments(spec *commandSpec, words []string, currentArg, beforeCursor string) []Suggest {
+	if spec.Complete != nil {
+		return spec.Complete(s, words, currentArg, beforeCursor)
+	}
+	// コンテナ内ブラウズ中は cd とファイル参照コマンドの引数をコンテナ内のパスで補完
+	if s.containerCwd != nil && (spec.Name == "cd" || containerFSCommands[spec.Name]) && !strings.HasPrefix(currentArg, "-") {
+		if _, _, ok := splitContainerPath(currentArg); !ok {
+			return s.completeBrowsePath(currentArg, spec.Name == "cd")
+		}
+	}
+	if spec.Name == "cd" {
+		if _, _, ok := splitContainerPath(currentArg); ok {
+			return s.completeContainerPath(currentArg)
+		}
+	}
+	if suggests, ok := s.completeOptions(spec.allOptions(), words, currentArg, beforeCursor, ""); ok {
+		return suggests
+	}
+	switch spec.Args {
+	case argContainer:
 		return s.completeDockerContainers(currentArg, false)
-	case "vi", "nano", "mkdir", "find", "locate":
+	case argRunningContainer:
 		return s.completeDockerContainers(currentArg, true)
-	case "netstat":
-		return s.completeDockerContainers(currentArg, false)
-	case "free", "top", "htop", "uname":
-		return []Suggest{}
-	case "df", "du":
-		return []Suggest{}
-	case "docker":
-		return s.completeDockerCommand(words, currentArg, beforeCursor)
-	case "project":
-		return s.completeProjectCommand(words, currentArg, beforeCursor)
-	case "theme":
+	case argImage:
+		return s.completeDockerImages(currentArg)
+	case argFile:
+		return s.completeFiles(currentArg)
+	case argDirectory:
+		return s.completeDirectories(currentArg)
+	case argPath:
+		return s.completeFilesAndDirectories(currentArg)
+	case argTheme:
 		return s.completeThemes(currentArg)
-	case "lang":
+	case argLanguage:
 		// lang 単体でも言語候補を提示したいので currentArg をそのまま
 		return s.completeLanguages(currentArg)
+	case argCommand:
+		return s.completeCommands(currentArg)
+	case argContainerPath:
+		return s.completeHostOrContainerPath(currentArg)
 	default:
-		return s.completeFilesAndDirectories(currentArg)
+		return []Suggest{}
 	}
 }
 
 // completeCommands はコマンド名の補完を提供します
 func (s *Shell) completeCommands(prefix string) []Suggest {
-	suggests := []Suggest{
-		{Text: "cd", Description: i18n.T("completion.descriptions.cd")},
-		{Text: "login", Description: i18n.T("completion.descriptions.login")},
-		{Text: "pwd", Description: i18n.T("completion.descriptions.pwd")},
-		{Text: "ls", Description: i18n.T("completion.descriptions.ls")},
-		{Text: "cat", Description: i18n.T("completion.descriptions.cat")},
-		{Text: "cp", Description: i18n.T("completion.descriptions.cp")},
-		{Text: "mv", Description: i18n.T("completion.descriptions.mv")},
-		{Text: "mkdir", Description: i18n.T("completion.descriptions.mkdir")},
-		{Text: "rmdir", Description: i18n.T("completion.descriptions.rmdir")},
-		{Text: "touch", Description: i18n.T("completion.descriptions.touch")},
-		{Text: "echo", Description: i18n.T("completion.descriptions.echo")},
-		{Text: "clear", Description: i18n.T("completion.descriptions.clear")},
-		{Text: "exit", Description: i18n.T("completion.descriptions.exit")},
-		{Text: "ps", Description: i18n.T("completion.descriptions.ps")},
-		{Text: "kill", Description: i18n.T("completion.descriptions.kill")},
-		{Text: "rm", Description: i18n.T("completion.descriptions.rm")},
-		{Text: "rmi", Description: i18n.T("completion.descriptions.rmi")},
-		{Text: "start", Description: i18n.T("completion.descriptions.start")},
-		{Text: "stop", Description: i18n.T("completion.descriptions.stop")},
-		{Text: "exec", Description: i18n.T("completion.descriptions.exec")},
-		{Text: "pull", Description: i18n.T("completion.descriptions.pull")},
-		{Text: "tail", Description: i18n.T("completion.descriptions.tail")},
-		{Text: "head", Description: i18n.T("completion.descriptions.head")},
-		{Text: "grep", Description: i18n.T("completion.descriptions.grep")},
-		{Text: "vi", Description: i18n.T("completion.descriptions.vi")},
-		{Text: "nano", Description: i18n.T("completion.descriptions.nano")},
-		{Text: "find", Description: i18n.T("completion.descriptions.find")},
-		{Text: "locate", Description: i18n.T("completion.descriptions.locate")},
-		{Text: "netstat", Description: i18n.T("completion.descriptions.netstat")},
-		{Text: "free", Description: i18n.T("completion.descriptions.free")},
-		{Text: "top", Description: i18n.T("completion.descriptions.top")},
-		{Text: "htop", Description: i18n.T("completion.descriptions.htop")},
-		{Text: "df", Description: i18n.T("completion.descriptions.df")},
-		{Text: "du", Description: i18n.T("completion.descriptions.du")},
-		{Text: "uname", Description: i18n.T("completion.descriptions.uname")},
-		{Text: "docker", Description: i18n.T("completion.descriptions.docker")},
-		{Text: "theme", Description: i18n.T("completion.descriptions.theme")},
-		{Text: "lang", Description: i18n.T("completion.descriptions.lang")},
-		{Text: "alias", Description: i18n.T("completion.descriptions.alias")},
-		{Text: "config", Description: i18n.T("completion.descriptions.config")},
-		{Text: "project", Description: "Docker Compose プロジェクト操作"},
-	}
-
-	if s.config != nil {
-		for alias := range s.config.Aliases {
-			suggests = append(suggests, Suggest{
-				Text:        alias,
-				Description: i18n.T("completion.alias_value", s.config.Aliases[alias]),
-			})
+	var suggests []Suggest
+	for _, spec := range s.registry.all() {
+		if spec.Hidden {
+			continue
 		}
+		suggests = append(suggests, Suggest{Text: spec.Name, Description: spec.description()})
+	}
+	for _, spec := range s.registry.aliasSpecs() {
+		suggests = append(suggests, Suggest{Text: spec.Name, Description: spec.description()})
 	}
 
-	return filterHasPrefix(suggests, prefix, true)
+	return filterFuzzy(suggests, prefix, true)
 }
 
 // completeFiles はファイル名の補完を提供します
@@ -245,14 +229,11 @@ func (s *Shell) completeFileSystem(prefix string, includeDirs, includeFiles bool
 
 // completeThemes はテーマの補完を提供します
 func (s *Shell) completeThemes(prefix string) []Suggest {
-	suggests := []Suggest{
-		{Text: "default", Description: "デフォルトテーマ"},
-		{Text: "minimal", Description: "ミニマルテーマ"},
-		{Text: "robbyrussell", Description: "Robby Russell テーマ"},
-		{Text: "agnoster", Description: "Agnoster テーマ"},
-		{Text: "pure", Description: "Pure テーマ"},
+	var suggests []Suggest
+	for _, name := range []string{"default", "minimal", "robbyrussell", "agnoster", "pure"} {
+		suggests = append(suggests, Suggest{Text: name, Description: i18n.T("completion.themes." + name)})
 	}
-	return filterHasPrefix(suggests, prefix, true)
+	return filterFuzzy(suggests, prefix, true)
 }
 
 // completeLanguages は言語の補完を提供します
@@ -263,45 +244,47 @@ func (s *Shell) completeLanguages(prefix string) []Suggest {
 
 // Docker補完関数群
 func (s *Shell) completeDockerContainers(prefix string, running bool) []Suggest {
-	containers := s.getDockerContainers(running)
+	key := cacheContainers
+	if running {
+		key = cacheRunningContainers
+	}
+	containers := s.completion.get(key)
 	if len(containers) == 0 {
 		return []Suggest{}
 	}
+	description := i18n.T("completion.value_container")
+	if running {
+		description = i18n.T("completion.value_running_container")
+	}
 	var suggests []Suggest
 	for _, container := range containers {
-		var description string
-		if running {
-			description = "実行中のコンテナ"
-		} else {
-			description = "コンテナ"
-		}
 		suggests = append(suggests, Suggest{Text: container, Description: description})
 	}
-	return filterHasPrefix(suggests, prefix, true)
+	return withPreviews(filterFuzzy(suggests, prefix, false), s.containerPreviews())
 }
 
 func (s *Shell) completeDockerImages(prefix string) []Suggest {
-	images := s.getDockerImages()
+	images := s.completion.get(cacheImages)
 	if len(images) == 0 {
 		return []Suggest{}
 	}
 	var suggests []Suggest
 	for _, image := range images {
-		suggests = append(suggests, Suggest{Text: image, Description: "Dockerイメージ"})
+		suggests = append(suggests, Suggest{Text: image, Description: i18n.T("completion.value_image")})
 	}
-	return filterHasPrefix(suggests, prefix, true)
+	return withPreviews(filterFuzzy(suggests, prefix, false), s.imagePreviews())
 }
 
 func (s *Shell) completeDockerNetworks(prefix string) []Suggest {
-	networks := s.getDockerNetworks()
+	networks := s.completion.get(cacheNetworks)
 	if len(networks) == 0 {
 		return []Suggest{}
 	}
 	var suggests []Suggest
 	for _, network := range networks {
-		suggests = append(suggests, Suggest{Text: network, Description: "Dockerネットワーク"})
+		suggests = append(suggests, Suggest{Text: network, Description: i18n.T("completion.value_network")})
 	}
-	return filterHasPrefix(suggests, prefix, true)
+	return filterFuzzy(suggests, prefix, false)
 }
 
 // completeProjectCommand は `project` の補完を提供します
@@ -309,106 +292,99 @@ func (s *Shell) completeProjectCommand(words []string, currentArg, beforeCursor
 	// project | project <name> | project <name> <sub>
 	if len(words) == 1 { // after typing 'project' and a space?
 		// 提案: ps と、現在検出できるプロジェクト名
-		suggests := []Suggest{{Text: "ps", Description: "全プロジェクト一覧"}}
+		suggests := []Suggest{{Text: "ps", Description: i18n.T("completion.project_subcommands.ps_all")}}
 		// 検出してプロジェクト名候補
 		projects := s.detectComposeProjects()
 		for _, p := range projects {
-			suggests = append(suggests, Suggest{Text: p, Description: "プロジェクト"})
+			suggests = append(suggests, Suggest{Text: p, Description: i18n.T("completion.value_project")})
 		}
-		return filterHasPrefix(suggests, currentArg, true)
+		return filterFuzzy(suggests, currentArg, false)
 	}
 
 	if len(words) == 2 { // project <name>
 		// サブコマンド候補
-		suggests := []Suggest{
-			{Text: "ps", Description: "プロジェクトのサービス一覧"},
-			{Text: "logs", Description: "サービスのログ"},
-			{Text: "start", Description: "サービス/プロジェクト開始"},
-			{Text: "restart", Description: "再起動"},
-			{Text: "stop", Description: "停止"},
+		var suggests []Suggest
+		for _, sub := range []string{"ps", "logs", "start", "restart", "stop", "up", "down", "build", "pull", "config", "graph"} {
+			suggests = append(suggests, Suggest{Text: sub, Description: i18n.T("completion.project_subcommands." + sub)})
 		}
-		return filterHasPrefix(suggests, currentArg, true)
+		return filterFuzzy(suggests, currentArg, false)
 	}
 
 	if len(words) >= 3 { // project <name> <sub>
 		sub := words[2]
-		// サービス名補完（ps 以外の時）
-		if sub == "logs" || sub == "start" || sub == "restart" || sub == "stop" {
-			services := s.detectComposeServices(words[1])
-			var suggests []Suggest
-			for _, sv := range services {
-				suggests = append(suggests, Suggest{Text: sv, Description: "サービス"})
+		if sub == "logs" {
+			if suggests, ok := s.completeOptions(muxLogsOptions, words, currentArg, beforeCursor, words[1]); ok {
+				return suggests
 			}
-			return filterHasPrefix(suggests, currentArg, true)
+		}
+		if sub == "graph" {
+			return filterFuzzy([]Suggest{{Text: "--dot", Description: i18n.T("completion.project_subcommands.graph_dot")}}, currentArg, false)
+		}
+		// サービス名補完（ps 以外の時）
+		switch sub {
+		case "logs", "start", "restart", "stop", "up", "build", "pull":
+			return s.completeServices(words[1], currentArg)
 		}
 	}
 	return []Suggest{}
 }
 
-// detectComposeProjects は稼働中/停止中コンテナの compose プロジェクト名を列挙
+// detectComposeProjects は稼働中/停止中コンテナの compose プロジェクト名を列挙（補完キャッシュから）
 func (s *Shell) detectComposeProjects() []string {
-	// シンプルに `docker ps -a --format` でラベル抽出
-	cmd := exec.Command("docker", "ps", "-a", "--format", "{{.Label \"com.docker.compose.project\"}}")
-	out, err := cmd.Output()
-	if err != nil {
-		return []string{}
-	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 	m := map[string]bool{}
-	for _, l := range lines {
-		l = strings.TrimSpace(l)
-		if l == "" || l == "<no value>" {
-			continue
+	var projects []string
+	for _, l := range s.completion.get(cacheCompose) {
+		p := strings.SplitN(l, "\t", 3)[0]
+		if !m[p] {
+			m[p] = true
+			projects = append(projects, p)
 		}
-		m[l] = true
 	}
-	var projects []string
-	for p := range m {
-		projects = append(projects, p)
+	// compose ファイルにだけ宣言されたプロジェクトも候補にする（ファイルの探索もキャッシュ経由）
+	for _, l := range s.completion.get(cacheComposeFilesPrefix + s.getCurrentDir()) {
+		p := strings.SplitN(l, "\t", 2)[0]
+		if !m[p] {
+			m[p] = true
+			projects = append(projects, p)
+		}
 	}
 	return projects
 }
 
-// detectComposeServices は指定プロジェクトのサービス名を列挙
+// detectComposeServices は指定プロジェクトのサービス名を列挙（補完キャッシュから）
 func (s *Shell) detectComposeServices(project string) []string {
-	// docker ps -a から該当プロジェクトの service ラベルを集める
-	format := "{{.Label \"com.docker.compose.project\"}}\t{{.Label \"com.docker.compose.service\"}}"
-	cmd := exec.Command("docker", "ps", "-a", "--format", format)
-	out, err := cmd.Output()
-	if err != nil {
-		return []string{}
-	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
 	m := map[string]bool{}
-	for _, l := range lines {
-		parts := strings.Split(l, "\t")
-		if len(parts) != 2 {
+	var services []string
+	for _, l := range s.completion.get(cacheCompose) {
+		parts := strings.SplitN(l, "\t", 3)
+		if len(parts) < 2 || parts[0] != project || parts[1] == "" || m[parts[1]] {
 			continue
 		}
-		if strings.TrimSpace(parts[0]) == project {
-			sv := strings.TrimSpace(parts[1])
-			if sv != "" && sv != "<no value>" {
-				m[sv] = true
-			}
-		}
+		m[parts[1]] = true
+		services = append(services, parts[1])
 	}
-	var services []string
-	for sv := range m {
-		services = append(services, sv)
+	// まだコンテナのないサービスは compose ファイルから補う（ファイルの探索もキャッシュ経由）
+	for _, l := range s.completion.get(cacheComposeFilesPrefix + s.getCurrentDir()) {
+		parts := strings.SplitN(l, "\t", 2)
+		if len(parts) < 2 || parts[0] != project || parts[1] == "" || m[parts[1]] {
+			continue
+		}
+		m[parts[1]] = true
+		services = append(services, parts[1])
 	}
 	return services
 }
 
 func (s *Shell) completeDockerVolumes(prefix string) []Suggest {
-	volumes := s.getDockerVolumes()
+	volumes := s.completion.get(cacheVolumes)
 	if len(volumes) == 0 {
 		return []Suggest{}
 	}
 	var suggests []Suggest
 	for _, volume := range volumes {
-		suggests = append(suggests, Suggest{Text: volume, Description: "Dockerボリューム"})
+		suggests = append(suggests, Suggest{Text: volume, Description: i18n.T("completion.value_volume")})
 	}
-	return filterHasPrefix(suggests, prefix, true)
+	return filterFuzzy(suggests, prefix, false)
 }
 
 func (s *Shell) completeDockerSubcommands(prefix string) []Suggest {
@@ -432,7 +408,83 @@ func (s *Shell) completeDockerSubcommands(prefix string) []Suggest {
 		{Text: "system", Description: i18n.T("completion.docker_subcommands.system")},
 		{Text: "version", Description: i18n.T("completion.docker_subcommands.version")},
 	}
-	return filterHasPrefix(suggests, prefix, true)
+	return filterFuzzy(suggests, prefix, true)
+}
+
+// dockerLogsOptions は docker logs（project <p> logs を含む）のオプションです
+var dockerLogsOptions = []optionSpec{
+	{Name: "--follow", Short: "-f", DescKey: "help.option_descriptions.follow"},
+	{Name: "--tail", Short: "-n", Type: valueInt, DescKey: "help.option_descriptions.lines"},
+	{Name: "--since", Type: valueDuration, DescKey: "help.option_descriptions.since"},
+	{Name: "--until", Type: valueDuration, DescKey: "help.option_descriptions.until"},
+	{Name: "--timestamps", Short: "-t", DescKey: "help.option_descriptions.timestamps"},
+}
+
+// dockerSubcommandOptions は docker <subcommand> で補完するネイティブオプションです
+var dockerSubcommandOptions = map[string][]optionSpec{
+	"logs": dockerLogsOptions,
+	"ps": {
+		{Name: "--all", Short: "-a", DescKey: "help.option_descriptions.all"},
+		{Name: "--quiet", Short: "-q", DescKey: "help.option_descriptions.quiet"},
+		{Name: "--filter", Type: valueString, DescKey: "help.option_descriptions.filter"},
+		{Name: "--format", Type: valueString, DescKey: "help.option_descriptions.format"},
+		{Name: "--last", Short: "-n", Type: valueInt, DescKey: "help.option_descriptions.last"},
+		{Name: "--size", Short: "-s", DescKey: "help.option_descriptions.size"},
+	},
+	"images": {
+		{Name: "--all", Short: "-a", DescKey: "help.option_descriptions.all"},
+		{Name: "--quiet", Short: "-q", DescKey: "help.option_descriptions.quiet"},
+		{Name: "--filter", Type: valueString, DescKey: "help.option_descriptions.filter"},
+		{Name: "--format", Type: valueString, DescKey: "help.option_descriptions.format"},
+	},
+	"rm": {
+		{Name: "--force", Short: "-f", DescKey: "help.option_descriptions.force"},
+		{Name: "--volumes", Short: "-v", DescKey: "help.option_descriptions.volumes"},
+	},
+	"rmi": {
+		{Name: "--force", Short: "-f", DescKey: "help.option_descriptions.force"},
+		{Name: "--no-prune", DescKey: "help.option_descriptions.no_prune"},
+	},
+	"stop": {
+		{Name: "--time", Short: "-t", Type: valueInt, DescKey: "help.option_descriptions.time"},
+	},
+	"restart": {
+		{Name: "--time", Short: "-t", Type: valueInt, DescKey: "help.option_descriptions.time"},
+	},
+	"start": {
+		{Name: "--attach", Short: "-a", DescKey: "help.option_descriptions.attach"},
+		{Name: "--interactive", Short: "-i", DescKey: "help.option_descriptions.interactive"},
+	},
+	"exec": {
+		{Name: "--interactive", Short: "-i", DescKey: "help.option_descriptions.interactive"},
+		{Name: "--tty", Short: "-t", DescKey: "help.option_descriptions.tty"},
+		{Name: "--detach", Short: "-d", DescKey: "help.option_descriptions.detach"},
+		{Name: "--user", Short: "-u", Type: valueString, DescKey: "help.option_descriptions.user"},
+		{Name: "--workdir", Short: "-w", Type: valueString, DescKey: "help.option_descriptions.workdir"},
+		{Name: "--env", Short: "-e", Type: valueString, DescKey: "help.option_descriptions.env"},
+	},
+	"run": {
+		{Name: "--detach", Short: "-d", DescKey: "help.option_descriptions.detach"},
+		{Name: "--interactive", Short: "-i", DescKey: "help.option_descriptions.interactive"},
+		{Name: "--tty", Short: "-t", DescKey: "help.option_descriptions.tty"},
+		{Name: "--rm", DescKey: "help.option_descriptions.rm"},
+		{Name: "--name", Type: valueString, DescKey: "help.option_descriptions.name"},
+		{Name: "--publish", Short: "-p", Type: valueString, DescKey: "help.option_descriptions.publish"},
+		{Name: "--env", Short: "-e", Type: valueString, DescKey: "help.option_descriptions.env"},
+		{Name: "--volume", Short: "-v", Type: valueString, DescKey: "help.option_descriptions.volume"},
+		{Name: "--network", Type: valueString, DescKey: "help.option_descriptions.network"},
+		{Name: "--restart", Type: valueEnum, Enum: []string{"no", "always", "on-failure", "unless-stopped"}, DescKey: "help.option_descriptions.restart"},
+	},
+	"pull": {
+		{Name: "--all-tags", Short: "-a", DescKey: "help.option_descriptions.all_tags"},
+		{Name: "--quiet", Short: "-q", DescKey: "help.option_descriptions.quiet"},
+		{Name: "--platform", Type: valueEnum, Enum: []string{"linux/amd64", "linux/arm64", "linux/arm/v7"}, DescKey: "help.option_descriptions.platform"},
+	},
+	"inspect": {
+		{Name: "--format", Short: "-f", Type: valueString, DescKey: "help.option_descriptions.format"},
+		{Name: "--size", Short: "-s", DescKey: "help.option_descriptions.size"},
+		{Name: "--type", Type: valueEnum, Enum: []string{"container", "image", "network", "volume"}, DescKey: "help.option_descriptions.type"},
+	},
 }
 
 func (s *Shell) completeDockerCommand(words []string, currentArg, beforeCursor string) []Suggest {
@@ -443,7 +495,14 @@ func (s *Shell) completeDockerCommand(words []string, currentArg, beforeCursor s
 		return []Suggest{}
 	}
 	subcommand := words[1]
+	if suggests, ok := s.completeOptions(dockerSubcommandOptions[subcommand], words, currentArg, beforeCursor, ""); ok {
+		return suggests
+	}
 	switch subcommand {
+	case "cp":
+		return s.completeHostOrContainerPath(currentArg)
+	case "exec":
+		return s.completeExecArguments(positionalArgs(words[2:], dockerSubcommandOptions["exec"]), currentArg, strings.HasSuffix(beforeCursor, " "))
 	case "rm":
 		if len(words) >= 3 && (len(words) > 3 || strings.HasSuffix(beforeCursor, " ")) {
 			return s.completeDockerContainers(currentArg, false)
@@ -451,9 +510,9 @@ func (s *Shell) completeDockerCommand(words []string, currentArg, beforeCursor s
 		return s.completeDockerContainers(currentArg, false)
 	case "rmi":
 		return s.completeDockerImages(currentArg)
-	case "stop", "restart", "exec", "logs", "inspect":
+	case "stop", "restart", "inspect":
 		return s.completeDockerContainers(currentArg, true)
-	case "start":
+	case "start", "logs":
 		return s.completeDockerContainers(currentArg, false)
 	case "run", "push":
 		return s.completeDockerImages(currentArg)
@@ -467,7 +526,7 @@ func (s *Shell) completeDockerCommand(words []string, currentArg, beforeCursor s
 				{Text: "rm", Description: i18n.T("completion.docker_network_subcommands.rm")},
 				{Text: "inspect", Description: i18n.T("completion.docker_network_subcommands.inspect")},
 			}
-			return filterHasPrefix(suggests, currentArg, true)
+			return filterFuzzy(suggests, currentArg, false)
 		}
 		if len(words) >= 4 && (words[2] == "rm" || words[2] == "inspect") {
 			return s.completeDockerNetworks(currentArg)
@@ -481,7 +540,7 @@ func (s *Shell) completeDockerCommand(words []string, currentArg, beforeCursor s
 				{Text: "rm", Description: i18n.T("completion.docker_volume_subcommands.rm")},
 				{Text: "inspect", Description: i18n.T("completion.docker_volume_subcommands.inspect")},
 			}
-			return filterHasPrefix(suggests, currentArg, true)
+			return filterFuzzy(suggests, currentArg, false)
 		}
 		if len(words) >= 4 && (words[2] == "rm" || words[2] == "inspect") {
 			return s.completeDockerVolumes(currentArg)
@@ -496,6 +555,6 @@ func (s *Shell) completeDockerCommand(words []string, currentArg, beforeCursor s
 
 // completeProjectTopLevel は "projec" 等の入力中にも project を優先的に提案する
 func (s *Shell) completeProjectTopLevel(prefix string) []Suggest {
-	suggests := []Suggest{{Text: "project", Description: "Docker Compose プロジェクト操作"}}
+	suggests := []Suggest{{Text: "project", Description: i18n.T("completion.descriptions.project")}}
 	return filterHasPrefix(suggests, prefix, true)
 }
@@ -0,0 +1,376 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 補完キャッシュのキー
+const (
+	cacheContainers        = "containers"
+	cacheRunningContainers = "running"
+	cacheImages            = "images"
+	cacheNetworks          = "networks"
+	cacheVolumes           = "volumes"
+	cacheCompose           = "compose"           // "project\tservice\tcontainer" 形式の行
+	cacheContainerDetails  = "container_details" // プレビュー用 "name\tstate\tstatus\timage\tports"
+	cacheImageDetails      = "image_details"     // プレビュー用 "repository:tag\tsize\tcreated"
+
+	// cachePathPrefix はコンテナ内ディレクトリ一覧のキーの接頭辞です（"path:container\tdir"）
+	cachePathPrefix = "path:"
+	// cacheComposeFilesPrefix はホストのディレクトリから見つかる compose ファイルのサービス一覧のキーの接頭辞です
+	// （"compose_files:dir"、値は "project\tservice"）
+	cacheComposeFilesPrefix = "compose_files:"
+	// cacheHealthPrefix はプロンプト用のコンテナの状態のキーの接頭辞です（"health:container"、値は "バッジ\t色"）
+	cacheHealthPrefix = "health:"
+)
+
+const (
+	// cacheLoadTimeout はローダー1回分の docker 呼び出しの期限です（応答しないデーモンで取得中のままにしない）
+	cacheLoadTimeout = 10 * time.Second
+	// maxPathEntries は保持するコンテナ内ディレクトリ一覧の上限です（超えたら最も使われていないものから捨てる）
+	maxPathEntries = 64
+	// eventsRetryMin と eventsRetryMax は docker events が終了した時の再接続の間隔です（失敗が続くと倍にしていく）
+	eventsRetryMin = time.Second
+	eventsRetryMax = time.Minute
+)
+
+// cacheLoader はキャッシュ1件分のデータを取得する関数です（ctx は cacheLoadTimeout で打ち切られます）
+type cacheLoader func(ctx context.Context) []string
+
+// cacheLoaderFactory はキーの接頭辞以降の部分からローダーを作る関数です（コンテナ内パスなど可変のキー用）
+type cacheLoaderFactory func(arg string) cacheLoader
+
+// cacheEntry はキャッシュ1件分の値と取得状態です
+type cacheEntry struct {
+	values  []string
+	fetched time.Time
+	loading bool
+	dirty   bool          // 取得中に無効化された（完了後に再取得する）
+	ready   chan struct{} // 初回取得の完了通知
+	used    time.Time     // 最後に参照された時刻（コンテナ内パスの破棄順に使う）
+}
+
+// completionCache は補完に使う Docker の一覧データを TTL 付きで保持します。
+// 取得はすべてバックグラウンドで行い、呼び出し側（REPL の UI ゴルーチン）をブロックしません。
+// docker events が使える場合は、イベントに応じて該当データを更新します。
+type completionCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	wait      time.Duration // 初回取得を待つ最大時間（REPL では 0）
+	loaders   map[string]cacheLoader
+	factories map[string]cacheLoaderFactory // キーの接頭辞 -> ローダー生成関数
+	entries   map[string]*cacheEntry
+	onUpdate  func()
+	eventsCmd *exec.Cmd
+	watching  bool
+	stopped   chan struct{} // stop で閉じられ、docker events の再接続を止める
+}
+
+func newCompletionCache(ttl time.Duration, loaders map[string]cacheLoader) *completionCache {
+	return &completionCache{
+		ttl:       ttl,
+		loaders:   loaders,
+		factories: make(map[string]cacheLoaderFactory),
+		entries:   make(map[string]*cacheEntry),
+		stopped:   make(chan struct{}),
+	}
+}
+
+// newShellCompletionCache はシェルの Docker 一覧取得関数をキャッシュに登録します
+func newShellCompletionCache(s *Shell, ttl time.Duration) *completionCache {
+	c := newCompletionCache(ttl, map[string]cacheLoader{
+		cacheContainers:        func(ctx context.Context) []string { return s.getDockerContainers(ctx, false) },
+		cacheRunningContainers: func(ctx context.Context) []string { return s.getDockerContainers(ctx, true) },
+		cacheImages:            s.getDockerImages,
+		cacheNetworks:          s.getDockerNetworks,
+		cacheVolumes:           s.getDockerVolumes,
+		cacheCompose:           s.getComposeLabels,
+		cacheContainerDetails:  s.getContainerDetails,
+		cacheImageDetails:      s.getImageDetails,
+	})
+	c.factories[cachePathPrefix] = func(arg string) cacheLoader {
+		parts := strings.SplitN(arg, "\t", 2)
+		if len(parts) != 2 {
+			return nil
+		}
+		return func(ctx context.Context) []string { return s.listContainerDir(ctx, parts[0], parts[1]) }
+	}
+	c.factories[cacheComposeFilesPrefix] = func(dir string) cacheLoader {
+		return func(context.Context) []string { return composeFileServices(dir) }
+	}
+	c.factories[cacheHealthPrefix] = func(arg string) cacheLoader {
+		return func(ctx context.Context) []string { return containerBadge(ctx, arg) }
+	}
+	return c
+}
+
+// get はキャッシュ済みの値を返します。期限切れや未取得の場合はバックグラウンドで取得を開始し、
+// 手元の値（未取得なら空）をすぐに返します。
+func (c *completionCache) get(key string) []string {
+	c.mu.Lock()
+	entry := c.entryLocked(key)
+	entry.used = time.Now()
+	if !entry.loading && (entry.fetched.IsZero() || time.Since(entry.fetched) > c.ttl) {
+		c.refreshLocked(key, entry)
+	}
+	values, fetched, wait := entry.values, entry.fetched, c.wait
+	c.mu.Unlock()
+
+	if fetched.IsZero() && wait > 0 {
+		select {
+		case <-entry.ready:
+		case <-time.After(wait):
+		}
+		c.mu.Lock()
+		values = entry.values
+		c.mu.Unlock()
+	}
+	return values
+}
+
+// prefetch は指定されたキーの取得を先行して開始します
+func (c *completionCache) prefetch(keys ...string) {
+	for _, key := range keys {
+		go c.get(key)
+	}
+}
+
+// invalidate は指定されたキーを期限切れにし、取得済みのものは再取得します。
+// 接頭辞（cacheHealthPrefix など）を渡すと、その接頭辞を持つすべてのキーが対象になります
+func (c *completionCache) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if _, ok := c.factories[key]; ok {
+			for k, entry := range c.entries {
+				if strings.HasPrefix(k, key) {
+					c.invalidateLocked(k, entry)
+				}
+			}
+			continue
+		}
+		if entry, ok := c.entries[key]; ok {
+			c.invalidateLocked(key, entry)
+		}
+	}
+}
+
+func (c *completionCache) invalidateLocked(key string, entry *cacheEntry) {
+	if entry.loading {
+		entry.dirty = true
+		return
+	}
+	c.refreshLocked(key, entry)
+}
+
+// setOnUpdate はデータ更新時に呼ばれる関数を設定します
+func (c *completionCache) setOnUpdate(fn func()) {
+	c.mu.Lock()
+	c.onUpdate = fn
+	c.mu.Unlock()
+}
+
+func (c *completionCache) entryLocked(key string) *cacheEntry {
+	entry, ok := c.entries[key]
+	if !ok {
+		if strings.HasPrefix(key, cachePathPrefix) {
+			c.evictPathLocked()
+		}
+		entry = &cacheEntry{ready: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+// evictPathLocked はコンテナ内ディレクトリ一覧が上限に達していれば、最も長く使われていないものを捨てます
+func (c *completionCache) evictPathLocked() {
+	count := 0
+	oldest := ""
+	for k, entry := range c.entries {
+		if !strings.HasPrefix(k, cachePathPrefix) {
+			continue
+		}
+		count++
+		if oldest == "" || entry.used.Before(c.entries[oldest].used) {
+			oldest = k
+		}
+	}
+	if count >= maxPathEntries {
+		delete(c.entries, oldest)
+	}
+}
+
+// refreshLocked はバックグラウンドで取得を開始します（c.mu を保持した状態で呼ぶこと）
+func (c *completionCache) refreshLocked(key string, entry *cacheEntry) {
+	loader := c.loaderFor(key)
+	if loader == nil {
+		// 取得できないキーは空の値で確定させ、待機中の呼び出し側を待たせない
+		if entry.fetched.IsZero() {
+			entry.fetched = time.Now()
+			close(entry.ready)
+		}
+		return
+	}
+	entry.loading = true
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cacheLoadTimeout)
+		values := loader(ctx)
+		cancel()
+
+		c.mu.Lock()
+		first := entry.fetched.IsZero()
+		entry.values = values
+		entry.fetched = time.Now()
+		entry.loading = false
+		if first {
+			close(entry.ready)
+		}
+		if entry.dirty {
+			entry.dirty = false
+			c.refreshLocked(key, entry)
+		}
+		onUpdate := c.onUpdate
+		c.mu.Unlock()
+
+		if onUpdate != nil {
+			onUpdate()
+		}
+	}()
+}
+
+// loaderFor はキーに対応するローダーを返します（固定キー、接頭辞付きキーの順に探す）
+func (c *completionCache) loaderFor(key string) cacheLoader {
+	if loader, ok := c.loaders[key]; ok {
+		return loader
+	}
+	for prefix, factory := range c.factories {
+		if strings.HasPrefix(key, prefix) {
+			return factory(strings.TrimPrefix(key, prefix))
+		}
+	}
+	return nil
+}
+
+// watchEvents は docker events を購読し、関連するキャッシュを更新します。
+// 購読が終了したら間隔を空けて再接続し、その間に起きた変更を取りこぼさないよう一覧を取り直します。
+// docker events が使えない間は TTL による更新だけになります。
+func (c *completionCache) watchEvents() {
+	c.mu.Lock()
+	if c.watching {
+		c.mu.Unlock()
+		return
+	}
+	c.watching = true
+	c.mu.Unlock()
+
+	go func() {
+		retry := eventsRetryMin
+		for {
+			started := time.Now()
+			if c.readEvents() && time.Since(started) > eventsRetryMax {
+				// しばらく購読できていたなら、次の再接続は最短の間隔から
+				retry = eventsRetryMin
+			}
+			select {
+			case <-c.stopped:
+				return
+			case <-time.After(retry):
+			}
+			retry = min(retry*2, eventsRetryMax)
+			c.invalidate(cacheContainers, cacheRunningContainers, cacheCompose, cacheContainerDetails, cacheHealthPrefix,
+				cacheImages, cacheImageDetails, cacheNetworks, cacheVolumes)
+		}
+	}()
+}
+
+// readEvents は docker events を1回起動し、終了するまでイベントに応じてキャッシュを更新します。
+// 起動できなかった場合は false を返します
+func (c *completionCache) readEvents() bool {
+	c.mu.Lock()
+	select {
+	case <-c.stopped:
+		c.mu.Unlock()
+		return false
+	default:
+	}
+	cmd := exec.Command("docker", "events", "--format", "{{.Type}}\t{{.Action}}")
+	c.eventsCmd = cmd
+	c.mu.Unlock()
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false
+	}
+	if err := cmd.Start(); err != nil {
+		return false
+	}
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if keys := cacheKeysForEvent(scanner.Text()); len(keys) > 0 {
+			c.invalidate(keys...)
+		}
+	}
+	_ = cmd.Wait()
+	return true
+}
+
+// stop は docker events の購読を終了し、再接続も止めます
+func (c *completionCache) stop() {
+	c.mu.Lock()
+	cmd := c.eventsCmd
+	c.eventsCmd = nil
+	c.onUpdate = nil
+	select {
+	case <-c.stopped:
+	default:
+		close(c.stopped)
+	}
+	c.mu.Unlock()
+	if cmd != nil && cmd.Process != nil {
+		_ = cmd.Process.Kill()
+	}
+}
+
+// cacheKeysForEvent は docker events の1行（Type\tAction）から更新が必要なキーを返します
+func cacheKeysForEvent(line string) []string {
+	parts := strings.SplitN(line, "\t", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	// exec_start: bash のように引数付きのアクションがあるため先頭だけを見る
+	fields := strings.Fields(parts[1])
+	if len(fields) == 0 {
+		return nil
+	}
+	action := strings.TrimSuffix(fields[0], ":")
+	switch parts[0] {
+	case "container":
+		switch action {
+		case "create", "destroy", "start", "stop", "die", "kill", "pause", "unpause", "rename", "restart":
+			return []string{cacheContainers, cacheRunningContainers, cacheCompose, cacheContainerDetails, cacheHealthPrefix}
+		case "health_status", "oom":
+			return []string{cacheHealthPrefix}
+		}
+	case "image":
+		switch action {
+		case "pull", "tag", "untag", "delete", "import", "load":
+			return []string{cacheImages}
+		}
+	case "network":
+		switch action {
+		case "create", "destroy", "remove":
+			return []string{cacheNetworks}
+		}
+	case "volume":
+		switch action {
+		case "create", "destroy", "remove":
+			return []string{cacheVolumes}
+		}
+	}
+	return nil
+}
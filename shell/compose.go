@@ -0,0 +1,507 @@
+package shell
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"docsh/i18n"
+)
+
+// composeFileNames は compose ファイルの既定の名前です（docker compose が探す順）
+var composeFileNames = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// composeProject はディスク上のファイルから見つけた compose プロジェクトです
+type composeProject struct {
+	Name       string
+	WorkingDir string
+	Files      []string // 先頭がメインのファイル、続いて override
+	Env        map[string]string
+	Services   []composeServiceDecl // 名前順
+}
+
+// composeServiceDecl は compose ファイルで宣言された1つのサービスです
+type composeServiceDecl struct {
+	Name          string
+	Image         string
+	ContainerName string
+	Build         bool
+	Profiles      []string
+	DependsOn     []composeDependency
+}
+
+// composeDependency は depends_on の1項目です。Condition は service_started、
+// service_healthy、service_completed_successfully のいずれかです。
+type composeDependency struct {
+	Service   string
+	Condition string
+}
+
+// composeFileData は compose ファイルのうち docsh が使う部分です
+type composeFileData struct {
+	Name     string                        `yaml:"name"`
+	Services map[string]composeServiceData `yaml:"services"`
+}
+
+type composeServiceData struct {
+	Image         string      `yaml:"image"`
+	ContainerName string      `yaml:"container_name"`
+	Build         interface{} `yaml:"build"`
+	Profiles      []string    `yaml:"profiles"`
+	DependsOn     interface{} `yaml:"depends_on"`
+}
+
+// composeCacheEntry は解析したプロジェクトをファイルが変わるまで保持します
+type composeCacheEntry struct {
+	signature string
+	project   *composeProject
+}
+
+var (
+	composeCacheMu sync.Mutex
+	composeCache   = map[string]composeCacheEntry{}
+)
+
+// findComposeFiles は dir で docker compose が使う compose ファイルを返します。
+// COMPOSE_FILE（環境変数または .env）があれば既定の名前より優先します。
+func findComposeFiles(dir string, env map[string]string) []string {
+	if list := env["COMPOSE_FILE"]; list != "" {
+		sep := env["COMPOSE_PATH_SEPARATOR"]
+		if sep == "" {
+			sep = string(os.PathListSeparator)
+		}
+		var files []string
+		for _, f := range strings.Split(list, sep) {
+			if f = strings.TrimSpace(f); f == "" {
+				continue
+			}
+			if !filepath.IsAbs(f) {
+				f = filepath.Join(dir, f)
+			}
+			if fileExists(f) {
+				files = append(files, f)
+			}
+		}
+		return files
+	}
+	for _, name := range composeFileNames {
+		main := filepath.Join(dir, name)
+		if !fileExists(main) {
+			continue
+		}
+		files := []string{main}
+		ext := filepath.Ext(name)
+		for _, overrideExt := range []string{ext, ".yaml", ".yml"} {
+			override := filepath.Join(dir, strings.TrimSuffix(name, ext)+".override"+overrideExt)
+			if fileExists(override) {
+				files = append(files, override)
+				break
+			}
+		}
+		return files
+	}
+	return nil
+}
+
+// loadDotEnv は .env ファイルの KEY=VALUE を読み込みます（ファイルが無ければ空）
+func loadDotEnv(path string) map[string]string {
+	env := map[string]string{}
+	file, err := os.Open(path)
+	if err != nil {
+		return env
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		env[key] = value
+	}
+	return env
+}
+
+// composeEnv はプロジェクトの .env とプロセスの環境変数をまとめます（compose と同じく環境変数が優先）
+func composeEnv(dir string) map[string]string {
+	env := loadDotEnv(filepath.Join(dir, ".env"))
+	for _, kv := range os.Environ() {
+		if parts := strings.SplitN(kv, "=", 2); len(parts) == 2 {
+			env[parts[0]] = parts[1]
+		}
+	}
+	return env
+}
+
+var interpolationPattern = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-?+])([^}]*))?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// interpolate は compose と同じように ${VAR}、${VAR:-default}、${VAR-default}、${VAR:+alt}、$VAR を展開します
+func interpolate(text string, env map[string]string) string {
+	return interpolationPattern.ReplaceAllStringFunc(text, func(m string) string {
+		if m == "$$" {
+			return "$"
+		}
+		sub := interpolationPattern.FindStringSubmatch(m)
+		name, op, arg := sub[1], sub[2], sub[3]
+		if name == "" {
+			name = sub[4]
+		}
+		value, set := env[name]
+		switch op {
+		case ":-":
+			if value == "" {
+				return arg
+			}
+		case "-":
+			if !set {
+				return arg
+			}
+		case ":+":
+			if value != "" {
+				return arg
+			}
+			return ""
+		case "+":
+			if set {
+				return arg
+			}
+			return ""
+		}
+		return value
+	})
+}
+
+var projectNameInvalidChars = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// normalizeProjectName は compose のプロジェクト名の規則（小文字、数字、'-'、'_'）に合わせます
+func normalizeProjectName(name string) string {
+	name = projectNameInvalidChars.ReplaceAllString(strings.ToLower(name), "")
+	return strings.TrimLeft(name, "-_")
+}
+
+// composeSignature はファイルの現在の内容をサイズと更新時刻で識別します
+func composeSignature(paths []string) string {
+	var b strings.Builder
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", p, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String()
+}
+
+// loadComposeProject は dir の compose ファイルを解析します。ファイルが無ければ nil を返します。
+func loadComposeProject(dir string) (*composeProject, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	env := composeEnv(dir)
+	files := findComposeFiles(dir, env)
+	if len(files) == 0 {
+		return nil, nil
+	}
+	signature := composeSignature(append([]string{filepath.Join(dir, ".env")}, files...))
+
+	composeCacheMu.Lock()
+	if entry, ok := composeCache[dir]; ok && entry.signature == signature {
+		composeCacheMu.Unlock()
+		return entry.project, nil
+	}
+	composeCacheMu.Unlock()
+
+	project := &composeProject{WorkingDir: dir, Files: files, Env: env}
+	services := map[string]composeServiceDecl{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var parsed composeFileData
+		if err := yaml.Unmarshal([]byte(interpolate(string(data), env)), &parsed); err != nil {
+			return nil, fmt.Errorf("%s: %v", f, err)
+		}
+		if parsed.Name != "" {
+			project.Name = parsed.Name
+		}
+		// 後のファイルは先に宣言されたサービスの項目を上書きする
+		for name, svc := range parsed.Services {
+			decl := services[name]
+			decl.Name = name
+			if svc.Image != "" {
+				decl.Image = svc.Image
+			}
+			if svc.ContainerName != "" {
+				decl.ContainerName = svc.ContainerName
+			}
+			if svc.Build != nil {
+				decl.Build = true
+			}
+			if len(svc.Profiles) > 0 {
+				decl.Profiles = svc.Profiles
+			}
+			if deps := parseDependsOn(svc.DependsOn); len(deps) > 0 {
+				decl.DependsOn = mergeDependencies(decl.DependsOn, deps)
+			}
+			services[name] = decl
+		}
+	}
+	if name := env["COMPOSE_PROJECT_NAME"]; name != "" {
+		project.Name = name
+	}
+	if project.Name == "" {
+		project.Name = filepath.Base(dir)
+	}
+	project.Name = normalizeProjectName(project.Name)
+	for _, decl := range services {
+		project.Services = append(project.Services, decl)
+	}
+	sort.Slice(project.Services, func(i, j int) bool { return project.Services[i].Name < project.Services[j].Name })
+
+	composeCacheMu.Lock()
+	composeCache[dir] = composeCacheEntry{signature: signature, project: project}
+	composeCacheMu.Unlock()
+	return project, nil
+}
+
+// parseDependsOn は depends_on の短い形式（リスト）と長い形式（condition 付きのマップ）を読み取ります
+func parseDependsOn(raw interface{}) []composeDependency {
+	var deps []composeDependency
+	switch v := raw.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if name, ok := item.(string); ok {
+				deps = append(deps, composeDependency{Service: name, Condition: "service_started"})
+			}
+		}
+	case map[interface{}]interface{}:
+		for key, value := range v {
+			dep := composeDependency{Service: fmt.Sprint(key), Condition: "service_started"}
+			if opts, ok := value.(map[interface{}]interface{}); ok {
+				if cond, ok := opts["condition"].(string); ok && cond != "" {
+					dep.Condition = cond
+				}
+			}
+			deps = append(deps, dep)
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Service < deps[j].Service })
+	return deps
+}
+
+// parseDependsOnLabel は com.docker.compose.depends_on ラベル（"db:service_healthy:false,cache:service_started:true"）を読み取ります
+func parseDependsOnLabel(label string) []composeDependency {
+	var deps []composeDependency
+	for _, entry := range strings.Split(label, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if parts[0] == "" {
+			continue
+		}
+		dep := composeDependency{Service: parts[0], Condition: "service_started"}
+		if len(parts) > 1 && parts[1] != "" {
+			dep.Condition = parts[1]
+		}
+		deps = append(deps, dep)
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Service < deps[j].Service })
+	return deps
+}
+
+// mergeDependencies は override ファイルの依存を追加します。同じサービスの項目は置き換えます
+func mergeDependencies(base, extra []composeDependency) []composeDependency {
+	merged := append([]composeDependency{}, extra...)
+	for _, dep := range base {
+		found := false
+		for _, e := range extra {
+			if e.Service == dep.Service {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, dep)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Service < merged[j].Service })
+	return merged
+}
+
+// service は指定した名前のサービスの宣言を返します
+func (p *composeProject) service(name string) (composeServiceDecl, bool) {
+	for _, svc := range p.Services {
+		if svc.Name == name {
+			return svc, true
+		}
+	}
+	return composeServiceDecl{}, false
+}
+
+// composeArgs はプロジェクト名、ディレクトリ、ファイルを固定する docker compose のグローバルオプションです
+func (p *composeProject) composeArgs() []string {
+	args := []string{"compose", "-p", p.Name, "--project-directory", p.WorkingDir}
+	for _, f := range p.Files {
+		args = append(args, "-f", f)
+	}
+	return args
+}
+
+// findComposeProjectUp は docker compose と同じように dir とその親ディレクトリから compose ファイルを探します
+func findComposeProjectUp(dir string) (*composeProject, error) {
+	for {
+		project, err := loadComposeProject(dir)
+		if project != nil || err != nil {
+			return project, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// discoverComposeProjects は現在のディレクトリのプロジェクトと、指定した作業ディレクトリ
+// （コンテナのラベルから取得）のプロジェクトを重複なく返します。
+func (s *Shell) discoverComposeProjects(workingDirs ...string) []*composeProject {
+	var projects []*composeProject
+	seen := map[string]bool{}
+	add := func(p *composeProject, err error) {
+		warnComposeError(err)
+		if p != nil && !seen[p.Name] {
+			seen[p.Name] = true
+			projects = append(projects, p)
+		}
+	}
+	// コンテナ内を閲覧中でも cwd はホストのディレクトリ
+	add(findComposeProjectUp(s.getCurrentDir()))
+	for _, dir := range workingDirs {
+		add(loadComposeProject(dir))
+	}
+	return projects
+}
+
+// composeFileServices は dir から上にたどって見つけた compose プロジェクトのサービスを
+// "project\tservice" の形で返します（サービスが無ければ "project\t"）。補完キャッシュがバックグラウンドで
+// 読み込むため、補完自体はファイルシステムを探したり YAML を解析したりしません。
+func composeFileServices(dir string) []string {
+	p, err := findComposeProjectUp(dir)
+	warnComposeError(err)
+	if p == nil {
+		return nil
+	}
+	if len(p.Services) == 0 {
+		return []string{p.Name + "\t"}
+	}
+	lines := make([]string, 0, len(p.Services))
+	for _, svc := range p.Services {
+		lines = append(lines, p.Name+"\t"+svc.Name)
+	}
+	return lines
+}
+
+var (
+	composeWarnedMu sync.Mutex
+	composeWarned   = map[string]bool{}
+)
+
+// warnComposeError は読み込めなかった compose ファイルをパスとエラー付きで標準エラーに1行で知らせます。
+// 同じエラーは1回だけ表示します。DOCSH_DEBUG=1 のときは発生するたびに表示します
+func warnComposeError(err error) {
+	if err == nil {
+		return
+	}
+	if os.Getenv("DOCSH_DEBUG") == "1" {
+		fmt.Fprintf(os.Stderr, "[DEBUG] compose parse error: %v\n", err)
+		return
+	}
+	msg := err.Error()
+	composeWarnedMu.Lock()
+	warned := composeWarned[msg]
+	composeWarned[msg] = true
+	composeWarnedMu.Unlock()
+	if !warned {
+		fmt.Fprintf(os.Stderr, i18n.T("compose.parse_warning")+"\n", msg)
+	}
+}
+
+// composeProjectByName はディスク上のプロジェクトを名前で探します（現在のディレクトリ、次にラベルの作業ディレクトリ）
+func (s *Shell) composeProjectByName(name string, workingDirs ...string) *composeProject {
+	for _, p := range s.discoverComposeProjects(workingDirs...) {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// collectAllProjects はコンテナから見つけたプロジェクトと compose ファイルで宣言されたプロジェクトを
+// まとめます。一度も作成されていないサービスも一覧に含めます。
+func (s *Shell) collectAllProjects() ([]projectGroup, error) {
+	groups, err := s.collectProjects()
+	if err != nil {
+		return nil, err
+	}
+	var workingDirs []string
+	for _, g := range groups {
+		workingDirs = append(workingDirs, g.WorkingDir)
+	}
+	for _, cp := range s.discoverComposeProjects(workingDirs...) {
+		var pg *projectGroup
+		for i := range groups {
+			if groups[i].ProjectName == cp.Name {
+				pg = &groups[i]
+				break
+			}
+		}
+		if pg == nil {
+			groups = append(groups, projectGroup{ProjectName: cp.Name, WorkingDir: cp.WorkingDir})
+			pg = &groups[len(groups)-1]
+		}
+		pg.Compose = cp
+		for i := range pg.Services {
+			_, declared := cp.service(pg.Services[i].ServiceName)
+			pg.Services[i].Declared = declared
+		}
+		for _, decl := range cp.Services {
+			if serviceIndex(pg, decl.Name) < 0 {
+				pg.Services = append(pg.Services, projectService{ServiceName: decl.Name, Declared: true})
+			}
+		}
+		sort.Slice(pg.Services, func(i, j int) bool { return pg.Services[i].ServiceName < pg.Services[j].ServiceName })
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ProjectName < groups[j].ProjectName })
+	return groups, nil
+}
+
+func serviceIndex(pg *projectGroup, name string) int {
+	for i, svc := range pg.Services {
+		if svc.ServiceName == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// execCompose はプロジェクトに対して docker compose を実行します（入出力はそのまま）
+func (s *Shell) execCompose(p *composeProject, action string, args ...string) error {
+	full := append(p.composeArgs()[1:], action)
+	return s.execDocker("compose", append(full, args...)...)
+}
@@ -0,0 +1,277 @@
+package shell
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"docsh/i18n"
+)
+
+// dependencyWaitTimeout は順序付き起動で1つの依存先を待つ上限です
+const dependencyWaitTimeout = 2 * time.Minute
+
+// serviceDependencies はプロジェクトの各サービスの depends_on を返します。
+// compose ファイルを優先し、無ければコンテナの com.docker.compose.depends_on ラベルを使います。
+func (pg *projectGroup) serviceDependencies() map[string][]composeDependency {
+	deps := map[string][]composeDependency{}
+	for _, svc := range pg.Services {
+		if pg.Compose != nil {
+			if decl, ok := pg.Compose.service(svc.ServiceName); ok {
+				deps[svc.ServiceName] = decl.DependsOn
+				continue
+			}
+		}
+		if svc.Container.DependsOn != "" {
+			deps[svc.ServiceName] = parseDependsOnLabel(svc.Container.DependsOn)
+		}
+	}
+	return deps
+}
+
+// serviceNames はプロジェクトのサービス名を表示順で返します
+func (pg *projectGroup) serviceNames() []string {
+	names := make([]string, 0, len(pg.Services))
+	for _, svc := range pg.Services {
+		names = append(names, svc.ServiceName)
+	}
+	return names
+}
+
+// dependencyOrder は各サービスが依存先の後に来るように並べます。
+// 互いに制約のないサービスはアルファベット順のままです。プロジェクトに無い依存先は無視します。
+func dependencyOrder(services []string, deps map[string][]composeDependency) ([]string, error) {
+	known := map[string]bool{}
+	for _, name := range services {
+		known[name] = true
+	}
+	pending := map[string]int{}
+	dependents := map[string][]string{}
+	for _, name := range services {
+		for _, dep := range deps[name] {
+			if known[dep.Service] && dep.Service != name {
+				pending[name]++
+				dependents[dep.Service] = append(dependents[dep.Service], name)
+			}
+		}
+	}
+
+	var ready, order []string
+	for _, name := range services {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, d := range dependents[name] {
+			if pending[d]--; pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	if len(order) < len(services) {
+		// 循環に巻き込まれているだけのサービスを除き、循環しているサービスだけをメッセージに出す
+		blocked := map[string]bool{}
+		for _, name := range services {
+			if pending[name] > 0 {
+				blocked[name] = true
+			}
+		}
+		for changed := true; changed; {
+			changed = false
+			for name := range blocked {
+				onCycle := false
+				for _, d := range dependents[name] {
+					if blocked[d] {
+						onCycle = true
+						break
+					}
+				}
+				if !onCycle {
+					delete(blocked, name)
+					changed = true
+				}
+			}
+		}
+		var cycle []string
+		for name := range blocked {
+			cycle = append(cycle, name)
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf(i18n.T("compose.dependency_cycle"), strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// startProjectInOrder はプロジェクトのコンテナを依存先から順に起動します。service_healthy と
+// service_completed_successfully の条件は、満たされるまで待ってから依存元を起動します。
+func (s *Shell) startProjectInOrder(pg *projectGroup) error {
+	deps := pg.serviceDependencies()
+	order, err := dependencyOrder(pg.serviceNames(), deps)
+	if err != nil {
+		return err
+	}
+	for _, name := range order {
+		svc := pg.Services[serviceIndex(pg, name)]
+		if svc.Container.Names == "" {
+			fmt.Printf("  ⏭️  %s: %s\n", name, i18n.T("compose.not_created"))
+			continue
+		}
+		for _, dep := range deps[name] {
+			if dep.Condition == "service_started" {
+				continue
+			}
+			i := serviceIndex(pg, dep.Service)
+			if i < 0 || pg.Services[i].Container.Names == "" {
+				continue
+			}
+			if err := waitForDependency(pg.Services[i].Container.Names, dep.Condition, dependencyWaitTimeout); err != nil {
+				return err
+			}
+		}
+		fmt.Printf(i18n.T("compose.starting")+"\n", name)
+		if err := s.execDocker("start", svc.Container.Names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopProjectInOrder はプロジェクトのコンテナを依存関係の逆順に停止します
+func (s *Shell) stopProjectInOrder(pg *projectGroup) error {
+	order, err := dependencyOrder(pg.serviceNames(), pg.serviceDependencies())
+	if err != nil {
+		return err
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		svc := pg.Services[serviceIndex(pg, order[i])]
+		if svc.Container.Names == "" {
+			continue
+		}
+		fmt.Printf(i18n.T("compose.stopping")+"\n", svc.ServiceName)
+		if err := s.execDocker("stop", svc.Container.Names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForDependency はコンテナが depends_on の条件を満たすまで1秒ごとに確認します（timeout まで）。
+// ヘルスチェックのないコンテナは起動していれば healthy とみなします。
+func waitForDependency(container, condition string, timeout time.Duration) error {
+	format := "{{.State.Status}} {{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}} {{.State.ExitCode}}"
+	deadline := time.Now().Add(timeout)
+	announced := false
+	for {
+		out, err := exec.Command("docker", "inspect", "-f", format, container).Output()
+		if err != nil {
+			return fmt.Errorf(i18n.T("compose.dependency_failed"), container, err)
+		}
+		fields := strings.Fields(string(out))
+		if len(fields) < 3 {
+			return fmt.Errorf(i18n.T("compose.dependency_failed"), container, strings.TrimSpace(string(out)))
+		}
+		status, health := fields[0], fields[1]
+		exitCode, _ := strconv.Atoi(fields[2])
+		switch condition {
+		case "service_completed_successfully":
+			if status == "exited" {
+				if exitCode == 0 {
+					return nil
+				}
+				return fmt.Errorf(i18n.T("compose.dependency_failed"), container, "exit "+fields[2])
+			}
+		default: // service_healthy
+			switch {
+			case health == "healthy", health == "none" && status == "running":
+				return nil
+			case health == "unhealthy":
+				return fmt.Errorf(i18n.T("compose.dependency_failed"), container, health)
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf(i18n.T("compose.dependency_timeout"), container, condition)
+		}
+		if !announced {
+			fmt.Printf(i18n.T("compose.waiting")+"\n", container, condition)
+			announced = true
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// printDependencyTree は最上位のサービス（どこからも依存されていないもの）ごとに、その下へ依存先を表示します
+func printDependencyTree(pg *projectGroup) {
+	deps := pg.serviceDependencies()
+	required := map[string]bool{}
+	for _, list := range deps {
+		for _, dep := range list {
+			required[dep.Service] = true
+		}
+	}
+	var roots []string
+	for _, name := range pg.serviceNames() {
+		if !required[name] {
+			roots = append(roots, name)
+		}
+	}
+	// すべてのサービスが依存されているのはグラフ全体が循環している場合だけ
+	if len(roots) == 0 {
+		roots = pg.serviceNames()
+	}
+
+	fmt.Printf("📦 %s\n", pg.ProjectName)
+	var walk func(name, condition, prefix string, last bool, path map[string]bool)
+	walk = func(name, condition, prefix string, last bool, path map[string]bool) {
+		branch, next := "├── ", "│   "
+		if last {
+			branch, next = "└── ", "    "
+		}
+		label := name
+		if condition != "" && condition != "service_started" {
+			label += " (" + condition + ")"
+		}
+		if path[name] {
+			fmt.Printf("%s%s%s ↻\n", prefix, branch, label)
+			return
+		}
+		fmt.Printf("%s%s%s\n", prefix, branch, label)
+		path[name] = true
+		children := deps[name]
+		for i, dep := range children {
+			walk(dep.Service, dep.Condition, prefix+next, i == len(children)-1, path)
+		}
+		delete(path, name)
+	}
+	for i, name := range roots {
+		walk(name, "", "", i == len(roots)-1, map[string]bool{})
+	}
+	fmt.Println()
+}
+
+// printDependencyDOT はグラフを Graphviz の DOT 形式で出力します（辺はサービスから依存先へ向かう）
+func printDependencyDOT(pg *projectGroup) {
+	deps := pg.serviceDependencies()
+	fmt.Printf("digraph %q {\n", pg.ProjectName)
+	fmt.Println("  rankdir=LR;")
+	for _, name := range pg.serviceNames() {
+		fmt.Printf("  %q;\n", name)
+	}
+	for _, name := range pg.serviceNames() {
+		for _, dep := range deps[name] {
+			if dep.Condition != "" && dep.Condition != "service_started" {
+				fmt.Printf("  %q -> %q [label=%q];\n", name, dep.Service, dep.Condition)
+			} else {
+				fmt.Printf("  %q -> %q;\n", name, dep.Service)
+			}
+		}
+	}
+	fmt.Println("}")
+}
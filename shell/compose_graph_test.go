@@ -0,0 +1,147 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDependencyOrder(t *testing.T) {
+	started := func(services ...string) []composeDependency {
+		var deps []composeDependency
+		for _, s := range services {
+			deps = append(deps, composeDependency{Service: s, Condition: "service_started"})
+		}
+		return deps
+	}
+	tests := []struct {
+		name      string
+		services  []string
+		deps      map[string][]composeDependency
+		want      []string
+		wantCycle []string // services named in the cycle error
+	}{
+		{
+			name:     "no dependencies keeps alphabetical order",
+			services: []string{"web", "api", "db"},
+			want:     []string{"api", "db", "web"},
+		},
+		{
+			name:     "linear",
+			services: []string{"api", "db", "web"},
+			deps:     map[string][]composeDependency{"web": started("api"), "api": started("db")},
+			want:     []string{"db", "api", "web"},
+		},
+		{
+			name:     "diamond",
+			services: []string{"api", "cache", "db", "web"},
+			deps: map[string][]composeDependency{
+				"web":   started("api", "cache"),
+				"api":   started("db"),
+				"cache": started("db"),
+			},
+			want: []string{"db", "api", "cache", "web"},
+		},
+		{
+			name:     "dependency outside the project is ignored",
+			services: []string{"api", "web"},
+			deps:     map[string][]composeDependency{"web": started("api", "missing"), "api": started("missing")},
+			want:     []string{"api", "web"},
+		},
+		{
+			name:     "self dependency is ignored",
+			services: []string{"api"},
+			deps:     map[string][]composeDependency{"api": started("api")},
+			want:     []string{"api"},
+		},
+		{
+			name:     "cycle names only its members",
+			services: []string{"a", "b", "c", "web"},
+			deps: map[string][]composeDependency{
+				"a":   started("b"),
+				"b":   started("c"),
+				"c":   started("a"),
+				"web": started("a"),
+			},
+			wantCycle: []string{"a", "b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dependencyOrder(tt.services, tt.deps)
+			if tt.wantCycle != nil {
+				if err == nil {
+					t.Fatalf("dependencyOrder() = %v, want a cycle error", got)
+				}
+				if !strings.Contains(err.Error(), strings.Join(tt.wantCycle, ", ")) || strings.Contains(err.Error(), "web") {
+					t.Errorf("cycle error %q, want members %v only", err, tt.wantCycle)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dependencyOrder() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependencyOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeDockerInspect puts a docker script on PATH whose inspect prints the given
+// "status health exitcode" line
+func fakeDockerInspect(t *testing.T, state string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as docker")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '" + state + "'\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestWaitForDependency(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     string
+		condition string
+		wantErr   bool
+	}{
+		{"healthy", "running healthy 0", "service_healthy", false},
+		{"running without health check", "running none 0", "service_healthy", false},
+		{"unhealthy fails at once", "running unhealthy 0", "service_healthy", true},
+		{"completed successfully", "exited none 0", "service_completed_successfully", false},
+		{"completed with an error", "exited none 1", "service_completed_successfully", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDockerInspect(t, tt.state)
+			err := waitForDependency("c", tt.condition, time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("waitForDependency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitForDependencyTimeout(t *testing.T) {
+	fakeDockerInspect(t, "running starting 0")
+	start := time.Now()
+	err := waitForDependency("c", "service_healthy", 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("waitForDependency() = nil, want a timeout error")
+	}
+	if !strings.Contains(err.Error(), "dependency_timeout") && !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("waitForDependency() error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waitForDependency() took %v after a 10ms timeout", elapsed)
+	}
+}
@@ -0,0 +1,172 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	env := map[string]string{"TAG": "1.25", "EMPTY": ""}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"nginx:${TAG}", "nginx:1.25"},
+		{"nginx:$TAG", "nginx:1.25"},
+		{"nginx:${MISSING}", "nginx:"},
+		{"${TAG:-latest}", "1.25"},
+		{"${MISSING:-latest}", "latest"},
+		{"${EMPTY:-latest}", "latest"},
+		{"${MISSING-latest}", "latest"},
+		{"${EMPTY-latest}", ""},
+		{"${TAG:+set}", "set"},
+		{"${EMPTY:+set}", ""},
+		{"${EMPTY+set}", "set"},
+		{"${MISSING+set}", ""},
+		{"$$TAG", "$TAG"},
+		{"${TAG:-a}-${MISSING:-b}", "1.25-b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := interpolate(tt.text, env); got != tt.want {
+				t.Errorf("interpolate(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	content := `# comment
+TAG=1.25
+export PORT=8080
+QUOTED="a # b"
+SINGLE='x'
+TRAILING=value # comment
+SPACED = padded
+not a pair
+`
+	path := filepath.Join(dir, ".env")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"TAG":      "1.25",
+		"PORT":     "8080",
+		"QUOTED":   "a # b",
+		"SINGLE":   "x",
+		"TRAILING": "value",
+		"SPACED":   "padded",
+	}
+	if got := loadDotEnv(path); !reflect.DeepEqual(got, want) {
+		t.Errorf("loadDotEnv() = %v, want %v", got, want)
+	}
+	if got := loadDotEnv(filepath.Join(dir, "missing")); len(got) != 0 {
+		t.Errorf("loadDotEnv(missing) = %v, want empty", got)
+	}
+}
+
+func TestComposeEnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("DOCSH_TEST_TAG=from-dotenv\nDOCSH_TEST_ONLY_DOTENV=yes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DOCSH_TEST_TAG", "from-env")
+	env := composeEnv(dir)
+	if got := env["DOCSH_TEST_TAG"]; got != "from-env" {
+		t.Errorf("DOCSH_TEST_TAG = %q, want the environment to win over .env", got)
+	}
+	if got := env["DOCSH_TEST_ONLY_DOTENV"]; got != "yes" {
+		t.Errorf("DOCSH_TEST_ONLY_DOTENV = %q, want the .env value", got)
+	}
+}
+
+func TestLoadComposeProject(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	compose := `services:
+  web:
+    image: nginx:${DOCSH_TEST_TAG:-latest}
+    depends_on: [api]
+  api:
+    build: .
+`
+	tests := []struct {
+		name      string
+		dotenv    string
+		env       map[string]string
+		override  string
+		wantName  string
+		wantImage string
+	}{
+		{
+			name:      "default from ${VAR:-default}",
+			wantName:  "app",
+			wantImage: "nginx:latest",
+		},
+		{
+			name:      ".env fills the variable and project name",
+			dotenv:    "DOCSH_TEST_TAG=1.25\nCOMPOSE_PROJECT_NAME=Shop.Front\n",
+			wantName:  "shopfront",
+			wantImage: "nginx:1.25",
+		},
+		{
+			name:      "environment wins over .env",
+			dotenv:    "DOCSH_TEST_TAG=1.25\n",
+			env:       map[string]string{"DOCSH_TEST_TAG": "1.27"},
+			wantName:  "app",
+			wantImage: "nginx:1.27",
+		},
+		{
+			name:      "override file replaces the image",
+			override:  "services:\n  web:\n    image: caddy\n",
+			wantName:  "app",
+			wantImage: "caddy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "app")
+			if err := os.Mkdir(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			write(t, dir, "compose.yaml", compose)
+			if tt.dotenv != "" {
+				write(t, dir, ".env", tt.dotenv)
+			}
+			if tt.override != "" {
+				write(t, dir, "compose.override.yaml", tt.override)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			project, err := loadComposeProject(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if project.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", project.Name, tt.wantName)
+			}
+			web, ok := project.service("web")
+			if !ok {
+				t.Fatal("service web not found")
+			}
+			if web.Image != tt.wantImage {
+				t.Errorf("web image = %q, want %q", web.Image, tt.wantImage)
+			}
+			if len(web.DependsOn) != 1 || web.DependsOn[0].Service != "api" {
+				t.Errorf("web depends_on = %v, want api", web.DependsOn)
+			}
+			if api, _ := project.service("api"); !api.Build {
+				t.Errorf("api should be built")
+			}
+		})
+	}
+}
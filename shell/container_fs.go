@@ -0,0 +1,180 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"docsh/i18n"
+	"docsh/internal/parser"
+)
+
+// containerLocation はコンテナ内ブラウズモードの現在位置（コンテナ名と絶対パス）です
+type containerLocation struct {
+	Container string
+	Dir       string
+}
+
+func (l containerLocation) String() string {
+	return l.Container + ":" + l.Dir
+}
+
+// containerFSCommands はブラウズ中にコンテナ内で実行するコマンドです
+var containerFSCommands = map[string]bool{
+	"ls":   true,
+	"cat":  true,
+	"head": true,
+	"tail": true,
+	"find": true,
+	"pwd":  true,
+}
+
+// runCd は cd を処理します。container:/path でコンテナ内ブラウズを開始し、
+// ブラウズ中はコンテナ内のパスとして解決します（引数なし、または / からの cd .. でホストに戻る）。
+func (s *Shell) runCd(args []string) error {
+	if len(args) > 0 {
+		if container, p, ok := splitContainerPath(args[0]); ok {
+			return s.enterContainerDir(container, p)
+		}
+	}
+	if s.containerCwd == nil {
+		return s.changeDirectory(args)
+	}
+
+	loc := *s.containerCwd
+	if len(args) == 0 || (args[0] == ".." && loc.Dir == "/") {
+		s.containerCwd = nil
+		fmt.Printf(i18n.T("container_fs.left")+"\n", s.getCurrentDir())
+		return nil
+	}
+	target := args[0]
+	if !path.IsAbs(target) {
+		target = path.Join(loc.Dir, target)
+	}
+	return s.enterContainerDir(loc.Container, target)
+}
+
+// enterContainerDir はコンテナ内のディレクトリをブラウズ位置に設定します。
+// docker exec -w <dir> pwd で存在確認とパスの正規化を同時に行います。
+func (s *Shell) enterContainerDir(container, dir string) error {
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+	if dir == "" {
+		dir = "/"
+	}
+	running, err := s.isContainerRunning(container)
+	if err != nil {
+		return fmt.Errorf(i18n.T("docker.error_checking_container_status"), err)
+	}
+	if !running {
+		return fmt.Errorf(i18n.T("docker.stop_not_running"), container)
+	}
+
+	output, err := exec.Command("docker", "exec", "-w", dir, container, "pwd").Output()
+	if err != nil {
+		return fmt.Errorf(i18n.T("container_fs.not_a_directory"), containerLocation{Container: container, Dir: dir})
+	}
+	resolved := strings.TrimSpace(string(output))
+	if resolved == "" {
+		resolved = path.Clean(dir)
+	}
+
+	entering := s.containerCwd == nil || s.containerCwd.Container != container
+	s.containerCwd = &containerLocation{Container: container, Dir: resolved}
+	if entering {
+		fmt.Printf(i18n.T("container_fs.entered")+"\n", s.containerCwd)
+	}
+	return nil
+}
+
+// currentLocation はプロンプトに表示する現在位置を返します（ブラウズ中は container:/path）
+func (s *Shell) currentLocation() string {
+	if s.containerCwd != nil {
+		return s.containerCwd.String()
+	}
+	return s.getCurrentDir()
+}
+
+// runContainerFSCommand はブラウズ中、または container:/path を引数に含む ls/cat/head/tail/find/pwd を
+// コンテナ内で実行します。対象外の場合は handled=false を返し、通常の処理に任せます。
+func (s *Shell) runContainerFSCommand(parsedCmd *parser.ParsedCommand) (bool, error) {
+	if !containerFSCommands[parsedCmd.Command] {
+		return false, nil
+	}
+
+	var loc containerLocation
+	if s.containerCwd != nil {
+		loc = *s.containerCwd
+	}
+	args := make([]string, 0, len(parsedCmd.RawArgs))
+	for _, arg := range parsedCmd.RawArgs {
+		if container, p, ok := splitContainerPath(arg); ok {
+			if loc.Container != "" && loc.Container != container {
+				return true, fmt.Errorf(i18n.T("container_fs.multiple_containers"), loc.Container, container)
+			}
+			if loc.Container != container {
+				loc = containerLocation{Container: container, Dir: "/"}
+			}
+			if p == "" {
+				p = "/"
+			}
+			arg = p
+		}
+		args = append(args, arg)
+	}
+	if loc.Container == "" {
+		return false, nil
+	}
+
+	if parsedCmd.Command == "pwd" {
+		fmt.Println(loc)
+		return true, nil
+	}
+
+	dockerCmd := append([]string{"docker", "exec", "-w", loc.Dir, loc.Container, parsedCmd.Command}, args...)
+	if parsedCmd.Command == "tail" && (parsedCmd.Options["f"] == "true" || parsedCmd.Options["follow"] == "true") {
+		return true, s.streamDockerCommand(dockerCmd, nil)
+	}
+
+	cmd := exec.Command(dockerCmd[0], dockerCmd[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// コマンド自身のエラーは標準エラー出力に表示済み
+			return true, nil
+		}
+		return true, err
+	}
+	return true, nil
+}
+
+// completeBrowsePath はブラウズ中のコンテナ内パスを補完します（相対パスは現在位置から解決）
+func (s *Shell) completeBrowsePath(arg string, dirsOnly bool) []Suggest {
+	loc := s.containerCwd
+	var suggests []Suggest
+	if path.IsAbs(arg) {
+		suggests = s.completeContainerFiles(loc.Container, arg)
+	} else {
+		base := strings.TrimSuffix(loc.Dir, "/") + "/"
+		for _, sg := range s.completeContainerFiles(loc.Container, base+arg) {
+			sg.Text = strings.TrimPrefix(sg.Text, base)
+			suggests = append(suggests, sg)
+		}
+	}
+	if !dirsOnly {
+		return suggests
+	}
+	var dirs []Suggest
+	for _, sg := range suggests {
+		if strings.HasSuffix(sg.Text, "/") {
+			dirs = append(dirs, sg)
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,120 @@
+package shell
+
+import (
+	"context"
+	"os/exec"
+	"path"
+	"runtime"
+	"strings"
+
+	"docsh/i18n"
+)
+
+// splitContainerPath は "web:/etc/nginx" 形式の引数をコンテナ名とパスに分けます。
+// Windows のドライブ指定（C:\ など）や URL はコンテナパスとして扱いません。
+func splitContainerPath(arg string) (container, p string, ok bool) {
+	i := strings.Index(arg, ":")
+	if i <= 0 {
+		return "", "", false
+	}
+	container, p = arg[:i], arg[i+1:]
+	if strings.ContainsAny(container, "/\\ ") {
+		return "", "", false
+	}
+	if p != "" && !strings.HasPrefix(p, "/") {
+		return "", "", false
+	}
+	if runtime.GOOS == "windows" && len(container) == 1 {
+		return "", "", false
+	}
+	return container, p, true
+}
+
+// listContainerDir はコンテナ内ディレクトリの一覧を返します（ディレクトリは末尾に / 付き）
+func (s *Shell) listContainerDir(ctx context.Context, container, dir string) []string {
+	output, err := exec.CommandContext(ctx, "docker", "exec", container, "ls", "-1Ap", dir).Output()
+	if err != nil {
+		return []string{}
+	}
+	var entries []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
+
+// completeContainerPath は "container:/path" 形式のパスをコンテナ内の一覧から補完します
+func (s *Shell) completeContainerPath(arg string) []Suggest {
+	container, p, ok := splitContainerPath(arg)
+	if !ok {
+		return []Suggest{}
+	}
+	var suggests []Suggest
+	for _, sg := range s.completeContainerFiles(container, p) {
+		suggests = append(suggests, Suggest{Text: container + ":" + sg.Text, Description: sg.Description})
+	}
+	return suggests
+}
+
+// completeContainerFiles はコンテナ内の絶対パスを補完します。
+// 一覧はディレクトリ単位で補完キャッシュに保持し、バックグラウンドで取得します。
+func (s *Shell) completeContainerFiles(container, p string) []Suggest {
+	if p == "" {
+		p = "/"
+	}
+	if !strings.HasPrefix(p, "/") {
+		return []Suggest{}
+	}
+	dir, base := p[:strings.LastIndex(p, "/")+1], p[strings.LastIndex(p, "/")+1:]
+	key := cachePathPrefix + container + "\t" + path.Clean(dir)
+
+	var suggests []Suggest
+	for _, name := range s.completion.get(key) {
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		desc := i18n.T("completion.entry_file")
+		if strings.HasSuffix(name, "/") {
+			desc = i18n.T("completion.entry_directory")
+		}
+		suggests = append(suggests, Suggest{Text: dir + name, Description: desc})
+	}
+	return suggests
+}
+
+// completeHostOrContainerPath は cp などの引数として、ホストのパスと "container:" 形式を補完します。
+// "container:" を含む入力はコンテナ内のパスを、それ以外はホストのファイルとコンテナ名を候補にします。
+func (s *Shell) completeHostOrContainerPath(arg string) []Suggest {
+	if _, _, ok := splitContainerPath(arg); ok {
+		return s.completeContainerPath(arg)
+	}
+	suggests := s.completeFilesAndDirectories(arg)
+	if arg == "" || strings.ContainsAny(arg, "/\\.") {
+		return suggests
+	}
+	for _, sg := range s.completeDockerContainers(arg, true) {
+		suggests = append(suggests, Suggest{Text: sg.Text + ":", Description: sg.Description, Preview: sg.Preview, Matches: sg.Matches})
+	}
+	return suggests
+}
+
+// completeExecArguments は exec <container> <command> [args...] の引数を補完します。
+// args は exec の後ろの単語列（オプションを除く）で、コマンドの引数位置では絶対パスをコンテナ内から補完します。
+func (s *Shell) completeExecArguments(args []string, currentArg string, newWord bool) []Suggest {
+	pos := len(args)
+	if !newWord {
+		pos--
+	}
+	switch {
+	case pos <= 0:
+		return s.completeDockerContainers(currentArg, true)
+	case pos == 1:
+		return []Suggest{}
+	case strings.HasPrefix(currentArg, "/"):
+		return s.completeContainerFiles(args[0], currentArg)
+	default:
+		return []Suggest{}
+	}
+}
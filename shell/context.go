@@ -0,0 +1,212 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+
+	"docsh/i18n"
+	"docsh/internal/parser"
+)
+
+// autoSwitchCommands はコンテナを明示した時に auto_switch でコンテキストを切り替えるコマンドです
+var autoSwitchCommands = map[string]bool{"login": true, "exec": true, "logs": true, "inspect": true}
+
+// currentContainer は use で設定された現在のコンテナを返します（未設定なら空）
+func (s *Shell) currentContainer() string {
+	if s.config == nil {
+		return ""
+	}
+	return s.config.CurrentContainer
+}
+
+// runUse は use [<container|service>|-|--clear] を処理します
+func (s *Shell) runUse(parsedCmd *parser.ParsedCommand) error {
+	if s.config == nil {
+		return fmt.Errorf(i18n.T("config.not_initialized"))
+	}
+	args := parsedCmd.RawArgs
+	if len(args) == 0 {
+		return s.showContext()
+	}
+	switch args[0] {
+	case "-":
+		previous := s.config.PreviousContainer
+		if previous == "" {
+			return fmt.Errorf(i18n.T("context.no_previous"))
+		}
+		return s.switchContext(previous)
+	case "--clear", "-c":
+		s.config.SetCurrentContainer("")
+		if err := s.config.SaveContext(); err != nil {
+			return fmt.Errorf(i18n.T("context.save_error"), err)
+		}
+		fmt.Println(i18n.T("context.container_cleared"))
+		return nil
+	}
+	container, err := s.resolveContextTarget(args[0])
+	if err != nil {
+		return err
+	}
+	return s.switchContext(container)
+}
+
+// switchContext は現在のコンテナを切り替えて保存します
+func (s *Shell) switchContext(container string) error {
+	if s.config == nil {
+		return fmt.Errorf(i18n.T("config.not_initialized"))
+	}
+	s.config.SetCurrentContainer(container)
+	if err := s.config.SaveContext(); err != nil {
+		return fmt.Errorf(i18n.T("context.save_error"), err)
+	}
+	fmt.Printf(i18n.T("context.container_set")+"\n", container)
+	return nil
+}
+
+// showContext は現在のコンテナと最近使ったコンテナを表示します
+func (s *Shell) showContext() error {
+	if s.config == nil {
+		return fmt.Errorf(i18n.T("config.not_initialized"))
+	}
+	if current := s.currentContainer(); current != "" {
+		fmt.Printf(i18n.T("context.current")+"\n", current)
+	} else {
+		fmt.Println(i18n.T("context.no_container_set"))
+	}
+	if len(s.config.RecentContainers) == 0 {
+		return nil
+	}
+	fmt.Println(i18n.T("context.recent_header"))
+	for _, name := range s.config.RecentContainers {
+		marker := " "
+		if name == s.config.CurrentContainer {
+			marker = "*"
+		}
+		fmt.Printf("  %s %s\n", marker, name)
+	}
+	return nil
+}
+
+// resolveContextTarget はコンテナ名、または一意に決まる compose サービス名をコンテナ名に解決します
+func (s *Shell) resolveContextTarget(name string) (string, error) {
+	exists, err := s.isDockerContainer(name)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return name, nil
+	}
+
+	groups, err := s.collectProjects()
+	if err != nil {
+		return "", err
+	}
+	var containers, projects []string
+	for _, g := range groups {
+		for _, svc := range g.Services {
+			if svc.ServiceName == name {
+				containers = append(containers, svc.Container.Names)
+				if !containsString(projects, g.ProjectName) {
+					projects = append(projects, g.ProjectName)
+				}
+			}
+		}
+	}
+	switch {
+	case len(containers) == 0:
+		return "", fmt.Errorf(i18n.T("context.container_not_found"), name)
+	case len(projects) > 1:
+		return "", fmt.Errorf(i18n.T("context.ambiguous_service"), name, strings.Join(projects, ", "))
+	}
+	// scale されたサービスは最初のコンテナを対象にする
+	return containers[0], nil
+}
+
+// contextTarget はコンテナ引数を省略したコマンドの対象となる現在のコンテナを返します。
+// コンテキストに対応しないコマンドや引数がある場合は空です。
+func (s *Shell) contextTarget(name string, args []string) string {
+	spec, ok := s.registry.lookup(name)
+	if !ok || !spec.Context || len(args) > 0 {
+		return ""
+	}
+	return s.currentContainer()
+}
+
+// applyContainerContext はコンテナ引数が省略されていれば現在のコンテナを補います。
+// コンテナを明示した場合は auto_switch に従ってコンテキストを切り替えます。
+func (s *Shell) applyContainerContext(spec *commandSpec, parsedCmd *parser.ParsedCommand) {
+	// --label で対象を選ぶ場合はコンテキストを使わない
+	if !spec.Context || hasLabelOption(parsedCmd.RawArgs) {
+		return
+	}
+	args := positionalArgs(parsedCmd.RawArgs, spec.allOptions())
+	current := s.currentContainer()
+
+	// exec は exec <cmd> と exec <container> <cmd> の両方を受け付ける
+	if spec.Name == "exec" && len(args) > 0 {
+		if exists, _ := s.isDockerContainer(args[0]); exists {
+			s.autoSwitchContext(spec, args[0])
+			return
+		}
+		if current == "" {
+			return
+		}
+		parsedCmd.Args = append([]string{current}, parsedCmd.Args...)
+		parsedCmd.RawArgs = append([]string{current}, parsedCmd.RawArgs...)
+		fmt.Printf(i18n.T("context.using")+"\n", current)
+		return
+	}
+
+	if len(args) > 0 {
+		s.autoSwitchContext(spec, args[0])
+		return
+	}
+	if current == "" {
+		return
+	}
+	parsedCmd.Args = append(parsedCmd.Args, current)
+	parsedCmd.RawArgs = append(parsedCmd.RawArgs, current)
+	fmt.Printf(i18n.T("context.using")+"\n", current)
+}
+
+// autoSwitchContext は auto_switch が有効なら明示されたコンテナを現在のコンテナにします
+func (s *Shell) autoSwitchContext(spec *commandSpec, container string) {
+	if s.config == nil || !s.config.ContextAutoSwitch || !autoSwitchCommands[spec.Name] || container == s.config.CurrentContainer {
+		return
+	}
+	if exists, _ := s.isDockerContainer(container); !exists {
+		return
+	}
+	s.config.SetCurrentContainer(container)
+	_ = s.config.SaveContext()
+}
+
+// completeUseTargets は use の候補（直前のコンテナ、最近使ったコンテナ、コンテナ、サービス）を返します
+func (s *Shell) completeUseTargets(prefix string) []Suggest {
+	var suggests []Suggest
+	seen := map[string]bool{}
+	if previous := s.config.PreviousContainer; previous != "" && (prefix == "" || prefix == "-") {
+		suggests = append(suggests, Suggest{Text: "-", Description: i18n.T("completion.value_previous_container", previous)})
+	}
+	var recent []Suggest
+	for _, name := range s.config.RecentContainers {
+		seen[name] = true
+		recent = append(recent, Suggest{Text: name, Description: i18n.T("completion.value_recent_container")})
+	}
+	suggests = append(suggests, withPreviews(filterFuzzy(recent, prefix, false), s.containerPreviews())...)
+
+	var others []Suggest
+	for _, sg := range s.completeDockerContainers(prefix, false) {
+		if !seen[sg.Text] {
+			seen[sg.Text] = true
+			others = append(others, sg)
+		}
+	}
+	for _, sg := range s.completeServices("", prefix) {
+		if !seen[sg.Text] {
+			seen[sg.Text] = true
+			others = append(others, sg)
+		}
+	}
+	return append(suggests, others...)
+}
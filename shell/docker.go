@@ -1,6 +1,7 @@
 package shell
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -407,18 +408,14 @@ func (s *Shell) removeImage(imageName string, force bool) error {
 // Docker補完関数群
 
 // getDockerContainers は全てのDockerコンテナ（実行中・停止中）を取得します
-func (s *Shell) getDockerContainers(running bool) []string {
-	if !s.shellExecutor.IsDockerAvailable() {
-		return []string{}
-	}
-
+func (s *Shell) getDockerContainers(ctx context.Context, running bool) []string {
 	var cmd *exec.Cmd
 	if running {
 		// 実行中のコンテナのみ
-		cmd = exec.Command("docker", "ps", "--format", "{{.Names}}")
+		cmd = exec.CommandContext(ctx, "docker", "ps", "--format", "{{.Names}}")
 	} else {
 		// 全てのコンテナ（実行中・停止中）
-		cmd = exec.Command("docker", "ps", "-a", "--format", "{{.Names}}")
+		cmd = exec.CommandContext(ctx, "docker", "ps", "-a", "--format", "{{.Names}}")
 	}
 
 	output, err := cmd.Output()
@@ -438,12 +435,8 @@ func (s *Shell) getDockerContainers(running bool) []string {
 }
 
 // getDockerImages はDockerイメージ一覧を取得します
-func (s *Shell) getDockerImages() []string {
-	if !s.shellExecutor.IsDockerAvailable() {
-		return []string{}
-	}
-
-	cmd := exec.Command("docker", "images", "--format", "{{.Repository}}:{{.Tag}}")
+func (s *Shell) getDockerImages(ctx context.Context) []string {
+	cmd := exec.CommandContext(ctx, "docker", "images", "--format", "{{.Repository}}:{{.Tag}}")
 	output, err := cmd.Output()
 	if err != nil {
 		return []string{}
@@ -460,13 +453,76 @@ func (s *Shell) getDockerImages() []string {
 	return images
 }
 
-// getDockerNetworks はDockerネットワーク一覧を取得します
-func (s *Shell) getDockerNetworks() []string {
-	if !s.shellExecutor.IsDockerAvailable() {
+// getComposeLabels は全コンテナの compose プロジェクト/サービスラベルを "project\tservice\tcontainer" 形式で取得します
+func (s *Shell) getComposeLabels(ctx context.Context) []string {
+	format := "{{.Label \"com.docker.compose.project\"}}\t{{.Label \"com.docker.compose.service\"}}\t{{.Names}}"
+	cmd := exec.CommandContext(ctx, "docker", "ps", "-a", "--format", format)
+	output, err := cmd.Output()
+	if err != nil {
 		return []string{}
 	}
 
-	cmd := exec.Command("docker", "network", "ls", "--format", "{{.Name}}")
+	var labels []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		project := strings.TrimSpace(parts[0])
+		if project == "" || project == "<no value>" {
+			continue
+		}
+		service, name := "", ""
+		if len(parts) >= 2 {
+			service = strings.TrimSpace(parts[1])
+			if service == "<no value>" {
+				service = ""
+			}
+		}
+		if len(parts) == 3 {
+			name = strings.TrimSpace(parts[2])
+		}
+		labels = append(labels, project+"\t"+service+"\t"+name)
+	}
+
+	return labels
+}
+
+// getContainerDetails は補完プレビュー用に全コンテナの概要を
+// "name\tstate\tstatus\timage\tports" 形式で取得します
+func (s *Shell) getContainerDetails(ctx context.Context) []string {
+	cmd := exec.CommandContext(ctx, "docker", "ps", "-a", "--format", "{{.Names}}\t{{.State}}\t{{.Status}}\t{{.Image}}\t{{.Ports}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return []string{}
+	}
+
+	var details []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			details = append(details, line)
+		}
+	}
+	return details
+}
+
+// getImageDetails は補完プレビュー用にイメージの概要を "repository:tag\tsize\tcreated" 形式で取得します
+func (s *Shell) getImageDetails(ctx context.Context) []string {
+	cmd := exec.CommandContext(ctx, "docker", "images", "--format", "{{.Repository}}:{{.Tag}}\t{{.Size}}\t{{.CreatedSince}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return []string{}
+	}
+
+	var details []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" && !strings.Contains(line, "<none>") {
+			details = append(details, line)
+		}
+	}
+	return details
+}
+
+// getDockerNetworks はDockerネットワーク一覧を取得します
+func (s *Shell) getDockerNetworks(ctx context.Context) []string {
+	cmd := exec.CommandContext(ctx, "docker", "network", "ls", "--format", "{{.Name}}")
 	output, err := cmd.Output()
 	if err != nil {
 		return []string{}
@@ -484,12 +540,8 @@ func (s *Shell) getDockerNetworks() []string {
 }
 
 // getDockerVolumes はDockerボリューム一覧を取得します
-func (s *Shell) getDockerVolumes() []string {
-	if !s.shellExecutor.IsDockerAvailable() {
-		return []string{}
-	}
-
-	cmd := exec.Command("docker", "volume", "ls", "--format", "{{.Name}}")
+func (s *Shell) getDockerVolumes(ctx context.Context) []string {
+	cmd := exec.CommandContext(ctx, "docker", "volume", "ls", "--format", "{{.Name}}")
 	output, err := cmd.Output()
 	if err != nil {
 		return []string{}
@@ -0,0 +1,223 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"docsh/i18n"
+	"docsh/internal/events"
+	"docsh/themes"
+)
+
+// eventsOptions は events が受け付けるオプションです
+var eventsOptions = []optionSpec{
+	{Name: "--type", Type: valueEnum, Enum: events.Types, DescKey: "help.option_descriptions.event_type"},
+	{Name: "--container", Short: "-c", Type: valueContainer, DescKey: "help.option_descriptions.event_container"},
+	{Name: "--project", Type: valueProject, DescKey: "help.option_descriptions.project_selector"},
+	{Name: "--since", Type: valueDuration, DescKey: "help.option_descriptions.event_since"},
+	{Name: "--until", Type: valueDuration, DescKey: "help.option_descriptions.event_until"},
+	{Name: "--all", Short: "-a", DescKey: "help.option_descriptions.event_all"},
+}
+
+// maxPausedEvents は一時停止中に溜めておくイベント数の上限です
+const maxPausedEvents = 1000
+
+// parseEventsArgs は events の引数を解釈します。位置引数はコンテナ名として扱います
+func parseEventsArgs(args []string) (events.Filter, bool, error) {
+	var (
+		filter events.Filter
+		all    bool
+	)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			filter.Containers = append(filter.Containers, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		opt, ok := findOption(eventsOptions, name)
+		if !ok {
+			return filter, false, fmt.Errorf(i18n.T("events.unknown_option")+"\n"+i18n.T("events.usage"), arg)
+		}
+		if opt.Type != valueNone && !hasValue {
+			if i+1 >= len(args) {
+				return filter, false, fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			i++
+			value = args[i]
+		}
+		switch opt.Name {
+		case "--type":
+			if !containsString(opt.Enum, value) {
+				return filter, false, fmt.Errorf(i18n.T("events.invalid_type"), value, strings.Join(opt.Enum, ", "))
+			}
+			filter.Types = append(filter.Types, value)
+		case "--container":
+			filter.Containers = append(filter.Containers, value)
+		case "--project":
+			filter.Project = value
+		case "--since":
+			filter.Since = value
+		case "--until":
+			filter.Until = value
+		case "--all":
+			all = true
+		}
+	}
+	return filter, all, nil
+}
+
+// runEvents は docker events を読みやすい形に整えて表示し続けます。
+// Enter で一時停止/再開、exit または q + Enter で終了します（--until 指定時はそこで終わります）
+func (s *Shell) runEvents(args []string) error {
+	filter, all, err := parseEventsArgs(args)
+	if err != nil {
+		return err
+	}
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "docker", filter.Args()...)
+	setShellProcessGroup(cmd)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	lines := make(chan string, 64)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Println(i18n.T("events.follow_tip"))
+	terminationChan := make(chan string, 5)
+	go s.watchForSignals(ctx, terminationChan)
+	pauseChan := make(chan struct{}, 1)
+	go watchForLogControl(ctx, terminationChan, pauseChan)
+
+	var (
+		pending []string
+		paused  bool
+		dropped int
+	)
+	show := func(line string) {
+		e, err := events.Parse(line)
+		if err != nil {
+			// JSON でない行（エラーなど）はそのまま表示する
+			fmt.Println(line)
+			return
+		}
+		if !all && !e.Lifecycle() {
+			return
+		}
+		text := formatEvent(e, s.config.Theme)
+		if paused {
+			pending = append(pending, text)
+			if len(pending) > maxPausedEvents {
+				dropped += len(pending) - maxPausedEvents
+				pending = pending[len(pending)-maxPausedEvents:]
+			}
+			return
+		}
+		fmt.Println(text)
+	}
+	for {
+		select {
+		case line := <-lines:
+			show(line)
+		case <-pauseChan:
+			paused = !paused
+			if paused {
+				fmt.Println(i18n.T("multilog.paused"))
+				continue
+			}
+			fmt.Printf(i18n.T("events.resumed")+"\n", len(pending), dropped)
+			for _, text := range pending {
+				fmt.Println(text)
+			}
+			pending, dropped = nil, 0
+		case <-done:
+			for len(lines) > 0 {
+				show(<-lines)
+			}
+			for _, text := range pending {
+				fmt.Println(text)
+			}
+			if err := cmd.Wait(); err != nil {
+				return fmt.Errorf("%s", firstLine(strings.TrimSpace(stderr.String()), err))
+			}
+			fmt.Println(i18n.T("app.command_completed"))
+			return nil
+		case reason := <-terminationChan:
+			cancel()
+			if strings.HasPrefix(reason, "signal") {
+				fmt.Println(i18n.T("app.command_stopped_signal"))
+			} else {
+				fmt.Println(i18n.T("app.command_stopped"))
+			}
+			return nil
+		}
+	}
+}
+
+// formatEvent はイベントを「時刻 種類 名前 [プロジェクト/サービス] 説明」の1行にします。種類と説明はイベントの色で表示します
+func formatEvent(e events.Event, theme string) string {
+	name := e.Name()
+	if project, service := e.Compose(); project != "" {
+		if service != "" {
+			project += "/" + service
+		}
+		name += " [" + project + "]"
+	}
+	color := e.Color()
+	return fmt.Sprintf("%s  %s  %s  %s",
+		e.Time().Local().Format("15:04:05"),
+		themes.Colorize(fmt.Sprintf("%-9s", e.Type), color),
+		name,
+		themes.Colorize(describeEvent(e), color))
+}
+
+// describeEvent はイベントの内容を現在の言語で説明します（訳がなければ docker のアクション名のまま）
+func describeEvent(e events.Event) string {
+	verb := e.Verb()
+	switch verb {
+	case "die":
+		if code := e.ExitCode(); code != "" {
+			return fmt.Sprintf(i18n.T("events.exited_code"), code)
+		}
+	case "health_status":
+		return fmt.Sprintf(i18n.T("events.health_status"), e.Detail())
+	case "kill":
+		if sig := e.Actor.Attributes["signal"]; sig != "" {
+			return fmt.Sprintf(i18n.T("events.killed_signal"), sig)
+		}
+	case "exec_create", "exec_start":
+		return fmt.Sprintf(i18n.T("events.exec"), e.Detail())
+	}
+	key := "events.actions." + verb
+	if text := i18n.T(key); text != key {
+		return text
+	}
+	return e.Action
+}
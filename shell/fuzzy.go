@@ -0,0 +1,133 @@
+package shell
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fuzzy マッチのスコア配分（fzf と同様に、連続一致と単語境界での一致を優遇します）
+const (
+	fuzzyScoreMatch       = 16
+	fuzzyBonusBoundary    = 10
+	fuzzyBonusConsecutive = 8
+	fuzzyBonusPrefix      = 24
+	fuzzyPenaltyGap       = 1
+	fuzzyMaxGapPenalty    = 8
+)
+
+// fuzzyMatch は pattern の文字が text に順番どおり含まれるかを調べ、スコアと一致位置（rune 単位）を返します。
+// pattern に大文字が含まれる場合のみ大文字小文字を区別します（smart case）。
+func fuzzyMatch(text, pattern string) (int, []int, bool) {
+	if pattern == "" {
+		return 0, nil, true
+	}
+	caseSensitive := strings.IndexFunc(pattern, unicode.IsUpper) >= 0
+	fold := func(r rune) rune {
+		if caseSensitive {
+			return r
+		}
+		return unicode.ToLower(r)
+	}
+	t := []rune(text)
+	p := []rune(pattern)
+	for i := range p {
+		p[i] = fold(p[i])
+	}
+
+	bestScore, found := 0, false
+	var bestPos []int
+	// 先頭文字の出現位置ごとに貪欲に一致させ、最もスコアの高いものを採用する
+	for start := range t {
+		if fold(t[start]) != p[0] {
+			continue
+		}
+		pos := make([]int, 0, len(p))
+		pi := 0
+		for ti := start; ti < len(t) && pi < len(p); ti++ {
+			if fold(t[ti]) == p[pi] {
+				pos = append(pos, ti)
+				pi++
+			}
+		}
+		if pi < len(p) {
+			break // これ以降の開始位置でも一致しない
+		}
+		if score := fuzzyScore(t, pos); !found || score > bestScore {
+			bestScore, bestPos, found = score, pos, true
+		}
+	}
+	return bestScore, bestPos, found
+}
+
+// fuzzyScore は一致位置からスコアを計算します
+func fuzzyScore(text []rune, pos []int) int {
+	score := 0
+	consecutive := 0
+	for i, ti := range pos {
+		score += fuzzyScoreMatch
+		if isWordBoundary(text, ti) {
+			score += fuzzyBonusBoundary
+		}
+		if i > 0 && ti == pos[i-1]+1 {
+			consecutive++
+			score += fuzzyBonusConsecutive * consecutive
+		} else {
+			consecutive = 0
+			if i > 0 {
+				score -= minInt(fuzzyMaxGapPenalty, (ti-pos[i-1]-1)*fuzzyPenaltyGap)
+			}
+		}
+	}
+	if pos[0] == 0 {
+		score += fuzzyBonusPrefix
+	} else {
+		score -= minInt(fuzzyMaxGapPenalty, pos[0]*fuzzyPenaltyGap)
+	}
+	// 同点なら短い候補を優先
+	score -= len(text) / 8
+	return score
+}
+
+// isWordBoundary は text[i] が単語の先頭（区切り文字の直後や camelCase の大文字）かを返します
+func isWordBoundary(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	if strings.ContainsRune("-_./: @", prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// filterFuzzy は fuzzy マッチで絞り込み、スコアの高い順に並べ替えます。
+// includeDescription が true の場合、名前に一致しない候補も説明の前方一致で残します（最下位）。
+func filterFuzzy(items []Suggest, pattern string, includeDescription bool) []Suggest {
+	if pattern == "" {
+		return items
+	}
+	type scored struct {
+		suggest Suggest
+		score   int
+	}
+	var matched []scored
+	for _, it := range items {
+		if score, pos, ok := fuzzyMatch(it.Text, pattern); ok {
+			it.Matches = pos
+			matched = append(matched, scored{suggest: it, score: score})
+			continue
+		}
+		if includeDescription && strings.HasPrefix(it.Description, pattern) {
+			it.Matches = nil
+			matched = append(matched, scored{suggest: it, score: -1 << 20})
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].score > matched[j].score })
+
+	out := make([]Suggest, len(matched))
+	for i, m := range matched {
+		out[i] = m.suggest
+	}
+	return out
+}
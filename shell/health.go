@@ -0,0 +1,126 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"docsh/i18n"
+	"docsh/internal/health"
+	"docsh/internal/parser"
+	"docsh/themes"
+)
+
+// runHealth はコンテナの状態、再起動、終了理由とヘルスチェックの履歴を表示します
+func (s *Shell) runHealth(parsedCmd *parser.ParsedCommand) error {
+	if len(parsedCmd.Args) == 0 {
+		return fmt.Errorf(i18n.T("docker.container_name_required"))
+	}
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+	containers, err := health.Inspect(parsedCmd.Args[0])
+	if err != nil {
+		return err
+	}
+	printHealth(containers[0])
+	return nil
+}
+
+// printHealth は inspect の結果を見出し付きで表示します
+func printHealth(c health.Container) {
+	st := c.Status()
+	var rows [][2]string
+	add := func(key, value string) { rows = append(rows, [2]string{i18n.T("health." + key), value}) }
+
+	add("state", themes.Colorize(st.Summary(), st.Color()))
+	add("started", health.FormatTime(c.State.StartedAt))
+	if st.State != "running" && st.State != "created" {
+		add("finished", health.FormatTime(c.State.FinishedAt))
+		exit := fmt.Sprint(st.ExitCode)
+		if reason := st.ExitReason(); reason != "" {
+			exit += " (" + themes.Colorize(reason, "red") + ")"
+		}
+		add("exit_code", exit)
+	}
+	if c.State.Error != "" {
+		add("error", themes.Colorize(c.State.Error, "red"))
+	}
+	add("restarts", fmt.Sprintf(i18n.T("health.restarts_value"), c.RestartCount, c.RestartPolicy()))
+
+	if check := c.Check(); check != "" {
+		if hc := c.Config.Healthcheck; hc.Interval > 0 {
+			check += "  " + fmt.Sprintf(i18n.T("health.interval"), time.Duration(hc.Interval), hc.Retries)
+		}
+		add("check", check)
+	}
+	h := c.State.Health
+	if h != nil {
+		add("check_status", themes.Colorize(h.Status, health.HealthColor(h.Status)))
+		add("failing_streak", fmt.Sprint(h.FailingStreak))
+	}
+
+	fmt.Println(themes.Colorize("📋 "+c.Name, "cyan"))
+	width := 0
+	for _, row := range rows {
+		width = max(width, lipgloss.Width(row[0]))
+	}
+	for _, row := range rows {
+		fmt.Printf("  %s%s  %s\n", row[0], strings.Repeat(" ", width-lipgloss.Width(row[0])), row[1])
+	}
+
+	fmt.Println()
+	switch {
+	case h == nil:
+		fmt.Println(i18n.T("health.no_healthcheck"))
+	case len(h.Log) == 0:
+		fmt.Println(i18n.T("health.no_log"))
+	default:
+		fmt.Println(i18n.T("health.log_header"))
+		// 新しい結果を先に表示する
+		for i := len(h.Log) - 1; i >= 0; i-- {
+			fmt.Println(formatProbe(h.Log[i]))
+		}
+	}
+}
+
+// formatProbe はヘルスチェック1回分を「時刻 所要時間 終了コード 出力」の1行にします
+func formatProbe(p health.Probe) string {
+	color := "green"
+	if p.ExitCode != 0 {
+		color = "red"
+	}
+	return fmt.Sprintf("  %s  %8s  %s  %s", health.FormatTime(p.Start), p.Took(), themes.Colorize(fmt.Sprintf("exit %d", p.ExitCode), color), p.OutputLine())
+}
+
+// containerBadge はプロンプトに出すコンテナの状態（"バッジ\t色"）を返します。
+// 取得できない場合や報告することがない場合は空です
+func containerBadge(ctx context.Context, name string) []string {
+	containers, err := health.InspectContext(ctx, name)
+	if err != nil {
+		return nil
+	}
+	st := containers[0].Status()
+	if badge := st.Badge(); badge != "" {
+		return []string{badge + "\t" + st.Color()}
+	}
+	return nil
+}
+
+// getContainerHealth は現在のコンテナの状態をキャッシュから返します（%H）。
+// 取得はバックグラウンドで行うため、プロンプトの描画を待たせません
+func (s *Shell) getContainerHealth() string {
+	container := s.currentContainer()
+	if container == "" || s.completion == nil {
+		return ""
+	}
+	values := s.completion.get(cacheHealthPrefix + container)
+	if len(values) == 0 {
+		return ""
+	}
+	badge, color, _ := strings.Cut(values[0], "\t")
+	return themes.Colorize(badge, color)
+}
@@ -0,0 +1,358 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"docsh/i18n"
+)
+
+// helpMapping は1件のマッピングのヘルプ表示用データです
+type helpMapping struct {
+	Linux       string
+	Docker      string
+	Description string
+	Example     string
+	Notes       []string
+	Warnings    []string
+}
+
+// helpOption は1件のオプションのヘルプ表示用データです
+type helpOption struct {
+	Flags       string
+	Description string
+}
+
+// helpEntry はレジストリのメタデータから組み立てた1コマンド分のヘルプです
+type helpEntry struct {
+	Name        string
+	Usage       string
+	Description string
+	AliasOf     string
+	Options     []helpOption
+	Mappings    []helpMapping
+	Examples    []string
+	Notes       []string
+	Warnings    []string
+}
+
+// helpSection は種別ごとにまとめたヘルプの見出しとコマンド一覧です
+type helpSection struct {
+	Title   string
+	Entries []helpEntry
+}
+
+// showHelp は help / help <command> を表示します
+func (s *Shell) showHelp(args []string) error {
+	if len(args) == 0 {
+		s.writeHelpOverview(os.Stdout)
+		return nil
+	}
+	entry, ok := s.helpEntryFor(args[0])
+	if !ok {
+		return fmt.Errorf(i18n.T("help.unknown_command"), args[0])
+	}
+	writeHelpEntry(os.Stdout, entry)
+	return nil
+}
+
+// HelpMarkdown は全コマンドのリファレンスを Markdown で返します（docsh --help-markdown 用）
+func (s *Shell) HelpMarkdown() string {
+	var b strings.Builder
+	s.writeHelpMarkdown(&b)
+	return b.String()
+}
+
+// helpEntryFor はコマンド名（エイリアスを含む）のヘルプを返します
+func (s *Shell) helpEntryFor(name string) (helpEntry, bool) {
+	spec, ok := s.registry.resolve(name)
+	if !ok {
+		return helpEntry{}, false
+	}
+	return buildHelpEntry(spec), true
+}
+
+// buildHelpEntry はコマンド定義とマッピングから現在の言語でヘルプを組み立てます
+func buildHelpEntry(spec *commandSpec) helpEntry {
+	lang := i18n.GetCurrentLanguage()
+	entry := helpEntry{
+		Name:        spec.Name,
+		Usage:       spec.Usage,
+		Description: spec.description(),
+		AliasOf:     spec.AliasOf,
+		Examples:    append([]string{}, spec.Examples...),
+	}
+	if entry.Usage == "" {
+		entry.Usage = spec.Name
+	}
+
+	for _, opt := range spec.allOptions() {
+		flags := strings.Join(nonEmpty(opt.Short, opt.Name), ", ")
+		if ph := opt.placeholder(); ph != "" {
+			flags += " " + ph
+		}
+		entry.Options = append(entry.Options, helpOption{Flags: flags, Description: opt.description()})
+	}
+
+	for _, m := range spec.Mappings {
+		hm := helpMapping{
+			Linux:       m.LinuxCommand,
+			Docker:      m.DockerCommand,
+			Description: m.DescriptionFor(lang),
+			Notes:       m.NotesFor(lang),
+			Warnings:    m.WarningsFor(lang),
+		}
+		if m.LinuxExample != "" && m.DockerExample != "" {
+			hm.Example = m.LinuxExample + "  ->  " + m.DockerExample
+		}
+		entry.Mappings = append(entry.Mappings, hm)
+	}
+
+	switch spec.Output {
+	case outputStreaming:
+		entry.Notes = append(entry.Notes, i18n.T("help.streaming_note"))
+	case outputInteractive:
+		entry.Notes = append(entry.Notes, i18n.T("help.interactive_note"))
+	}
+	if spec.Context {
+		entry.Notes = append(entry.Notes, i18n.T("help.context_note"))
+	}
+	if spec.Safety == safetyDestructive {
+		entry.Warnings = append(entry.Warnings, i18n.T("help.destructive_warning"))
+	}
+	return entry
+}
+
+// helpSections はレジストリ全体を種別ごとのヘルプ見出しにまとめます
+func (s *Shell) helpSections() []helpSection {
+	sections := []helpSection{
+		{Title: i18n.T("commands.docker_only_mappings_title")},
+		{Title: i18n.T("commands.lifecycle_header")},
+		{Title: i18n.T("commands.docker_only_builtin_commands_title")},
+		{Title: i18n.T("help.aliases_title")},
+	}
+	for _, spec := range s.registry.all() {
+		if spec.Hidden {
+			continue
+		}
+		idx := 2
+		switch spec.Kind {
+		case kindMapping:
+			idx = 0
+		case kindLifecycle:
+			idx = 1
+		}
+		sections[idx].Entries = append(sections[idx].Entries, buildHelpEntry(spec))
+	}
+
+	aliases := s.registry.aliasSpecs()
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	for _, spec := range aliases {
+		sections[3].Entries = append(sections[3].Entries, buildHelpEntry(spec))
+	}
+
+	var result []helpSection
+	for _, section := range sections {
+		if len(section.Entries) > 0 {
+			result = append(result, section)
+		}
+	}
+	return result
+}
+
+// writeHelpOverview はコマンド一覧形式のヘルプを書き出します
+func (s *Shell) writeHelpOverview(w io.Writer) {
+	fmt.Fprintln(w, i18n.T("commands.docker_only_help_title"))
+	fmt.Fprintln(w, i18n.T("commands.docker_only_help_description"))
+	fmt.Fprintln(w)
+
+	for _, section := range s.helpSections() {
+		fmt.Fprintln(w, section.Title)
+		for _, entry := range section.Entries {
+			usage := entry.Usage
+			if entry.AliasOf != "" {
+				usage = entry.Name
+			}
+			if len(usage) > 36 {
+				fmt.Fprintf(w, "  %s\n  %-36s %s\n", usage, "", entry.Description)
+				continue
+			}
+			fmt.Fprintf(w, "  %-36s %s\n", usage, entry.Description)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, i18n.T("help.more_info"))
+	fmt.Fprintln(w, i18n.T("commands.docker_only_note_title")+" "+i18n.T("commands.docker_only_note_message"))
+}
+
+// writeHelpEntry は1コマンド分の詳細ヘルプを書き出します
+func writeHelpEntry(w io.Writer, entry helpEntry) {
+	fmt.Fprintf(w, "%s - %s\n", entry.Name, entry.Description)
+
+	if entry.AliasOf != "" {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s:\n  %s\n", i18n.T("help.usage"), entry.Usage)
+
+	if len(entry.Options) > 0 {
+		fmt.Fprintf(w, "\n%s:\n", i18n.T("help.options"))
+		for _, opt := range entry.Options {
+			fmt.Fprintf(w, "  %-16s %s\n", opt.Flags, opt.Description)
+		}
+	}
+
+	if len(entry.Mappings) > 0 {
+		fmt.Fprintf(w, "\n%s:\n", i18n.T("help.mappings"))
+		for _, m := range entry.Mappings {
+			fmt.Fprintf(w, "  %s -> %s\n", m.Linux, m.Docker)
+			if m.Description != "" {
+				fmt.Fprintf(w, "    %s\n", m.Description)
+			}
+			for _, note := range m.Notes {
+				fmt.Fprintf(w, "    - %s\n", note)
+			}
+			for _, warning := range m.Warnings {
+				fmt.Fprintf(w, "    ⚠️  %s\n", warning)
+			}
+		}
+	}
+
+	examples := entryExamples(entry)
+	if len(examples) > 0 {
+		fmt.Fprintf(w, "\n%s:\n", i18n.T("help.examples"))
+		for _, example := range examples {
+			fmt.Fprintf(w, "  %s\n", example)
+		}
+	}
+
+	if len(entry.Notes) > 0 {
+		fmt.Fprintf(w, "\n%s:\n", i18n.T("help.notes"))
+		for _, note := range entry.Notes {
+			fmt.Fprintf(w, "  - %s\n", note)
+		}
+	}
+
+	if len(entry.Warnings) > 0 {
+		fmt.Fprintf(w, "\n%s:\n", i18n.T("help.warnings"))
+		for _, warning := range entry.Warnings {
+			fmt.Fprintf(w, "  ⚠️  %s\n", warning)
+		}
+	}
+}
+
+// writeHelpMarkdown は全コマンドのリファレンスを Markdown で書き出します
+func (s *Shell) writeHelpMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# %s\n\n", i18n.T("help.markdown_title"))
+	fmt.Fprintf(w, "%s\n\n", i18n.T("commands.docker_only_help_description"))
+
+	sections := s.helpSections()
+	for _, section := range sections {
+		fmt.Fprintf(w, "## %s\n\n", markdownTitle(section.Title))
+		fmt.Fprintf(w, "| %s | %s |\n|---|---|\n", i18n.T("help.command"), i18n.T("help.description"))
+		for _, entry := range section.Entries {
+			usage := entry.Usage
+			if entry.AliasOf != "" {
+				usage = entry.Name
+			}
+			fmt.Fprintf(w, "| `%s` | %s |\n", markdownCell(usage), markdownCell(entry.Description))
+		}
+		fmt.Fprintln(w)
+	}
+
+	for _, section := range sections {
+		for _, entry := range section.Entries {
+			if entry.AliasOf != "" {
+				continue
+			}
+			writeHelpEntryMarkdown(w, entry)
+		}
+	}
+}
+
+// writeHelpEntryMarkdown は1コマンド分の詳細を Markdown で書き出します
+func writeHelpEntryMarkdown(w io.Writer, entry helpEntry) {
+	fmt.Fprintf(w, "### `%s`\n\n%s\n\n", entry.Name, entry.Description)
+	fmt.Fprintf(w, "**%s**\n\n```\n%s\n```\n\n", i18n.T("help.usage"), entry.Usage)
+
+	if len(entry.Options) > 0 {
+		fmt.Fprintf(w, "**%s**\n\n", i18n.T("help.options"))
+		for _, opt := range entry.Options {
+			fmt.Fprintf(w, "- `%s`: %s\n", opt.Flags, opt.Description)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(entry.Mappings) > 0 {
+		fmt.Fprintf(w, "**%s**\n\n", i18n.T("help.mappings"))
+		fmt.Fprintf(w, "| Linux | Docker | %s |\n|---|---|---|\n", i18n.T("help.description"))
+		for _, m := range entry.Mappings {
+			fmt.Fprintf(w, "| `%s` | `%s` | %s |\n", markdownCell(m.Linux), markdownCell(m.Docker), markdownCell(m.Description))
+		}
+		fmt.Fprintln(w)
+	}
+
+	examples := entryExamples(entry)
+	if len(examples) > 0 {
+		fmt.Fprintf(w, "**%s**\n\n```\n%s\n```\n\n", i18n.T("help.examples"), strings.Join(examples, "\n"))
+	}
+
+	var notes, warnings []string
+	for _, m := range entry.Mappings {
+		notes = append(notes, m.Notes...)
+		warnings = append(warnings, m.Warnings...)
+	}
+	notes = append(notes, entry.Notes...)
+	warnings = append(warnings, entry.Warnings...)
+
+	if len(notes) > 0 {
+		fmt.Fprintf(w, "**%s**\n\n", i18n.T("help.notes"))
+		for _, note := range notes {
+			fmt.Fprintf(w, "- %s\n", note)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(warnings) > 0 {
+		fmt.Fprintf(w, "**%s**\n\n", i18n.T("help.warnings"))
+		for _, warning := range warnings {
+			fmt.Fprintf(w, "> ⚠️ %s\n", warning)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// entryExamples はコマンド固有の例とマッピングの変換例をまとめて返します
+func entryExamples(entry helpEntry) []string {
+	examples := append([]string{}, entry.Examples...)
+	for _, m := range entry.Mappings {
+		if m.Example != "" {
+			examples = append(examples, m.Example)
+		}
+	}
+	return examples
+}
+
+// markdownTitle は見出し文字列から末尾のコロンを取り除きます
+func markdownTitle(title string) string {
+	return strings.TrimRight(strings.TrimSpace(title), ":：")
+}
+
+// markdownCell は表のセルで使えない文字をエスケープします
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// nonEmpty は空文字列を除いたスライスを返します
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
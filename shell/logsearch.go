@@ -0,0 +1,420 @@
+package shell
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"docsh/i18n"
+	"docsh/internal/parser"
+	"docsh/themes"
+)
+
+// defaultLogSearchSince は --since を省略したときに検索する期間です。
+// 全履歴を読み込むとログの量だけメモリと時間を使うため、既定では直近に限ります（--since 0 で全期間）
+const defaultLogSearchSince = "1h"
+
+// logSearchOptions は logs search が受け付けるオプションです
+var logSearchOptions = []optionSpec{
+	{Name: "--since", Type: valueDuration, DescKey: "help.option_descriptions.since"},
+	{Name: "--until", Type: valueDuration, DescKey: "help.option_descriptions.until"},
+	{Name: "--project", Type: valueProject, DescKey: "help.option_descriptions.project_selector"},
+	labelOption,
+	{Name: "--where", Type: valueString, DescKey: "help.option_descriptions.where"},
+	{Name: "--context", Short: "-C", Type: valueInt, DescKey: "help.option_descriptions.context_lines"},
+	{Name: "--before-context", Short: "-B", Type: valueInt, DescKey: "help.option_descriptions.before_context"},
+	{Name: "--after-context", Short: "-A", Type: valueInt, DescKey: "help.option_descriptions.after_context"},
+	{Name: "--ignore-case", Short: "-i", DescKey: "help.option_descriptions.ignore_case"},
+}
+
+// fieldCondition は JSON ログのフィールドに対する条件です（level=error, status!=200, msg~timeout）
+type fieldCondition struct {
+	Key   string // http.status のようにドット区切りで入れ子を指定
+	Op    string // "=", "!=", "~"
+	Value string
+	re    *regexp.Regexp
+}
+
+// logQuery は logs search の検索条件です。すべての条件を満たす行が一致します。
+type logQuery struct {
+	Pattern *regexp.Regexp // 行全体に対する正規表現（フィールド条件だけの場合は nil）
+	Fields  []fieldCondition
+}
+
+var fieldConditionPattern = regexp.MustCompile(`^([A-Za-z_@][A-Za-z0-9_.@-]*)(!=|~|=)(.*)$`)
+
+// parseFieldCondition は key=value / key!=value / key~regex 形式ならフィールド条件を返します
+func parseFieldCondition(text string, ignoreCase bool) (fieldCondition, bool, error) {
+	m := fieldConditionPattern.FindStringSubmatch(text)
+	if m == nil {
+		return fieldCondition{}, false, nil
+	}
+	cond := fieldCondition{Key: m[1], Op: m[2], Value: m[3]}
+	if cond.Op == "~" {
+		expr := cond.Value
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return cond, true, fmt.Errorf(i18n.T("selector.invalid_regex"), cond.Value, err)
+		}
+		cond.re = re
+	}
+	return cond, true, nil
+}
+
+// lookupField は JSON オブジェクトからドット区切りのキーの値を文字列で取り出します
+func lookupField(obj map[string]interface{}, key string) (string, bool) {
+	var value interface{} = obj
+	// "http.status" のようなキー名そのものを優先し、無ければ入れ子として辿る
+	if v, ok := obj[key]; ok {
+		value = v
+	} else {
+		for _, part := range strings.Split(key, ".") {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			if value, ok = m[part]; !ok {
+				return "", false
+			}
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case nil:
+		return "null", true
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// matches はフィールド条件に一致するかを返します（キーが無い場合は != のみ一致）
+func (c fieldCondition) matches(obj map[string]interface{}) bool {
+	value, ok := lookupField(obj, c.Key)
+	switch c.Op {
+	case "!=":
+		return !ok || !strings.EqualFold(value, c.Value)
+	case "~":
+		return ok && c.re.MatchString(value)
+	default:
+		return ok && strings.EqualFold(value, c.Value)
+	}
+}
+
+// parseJSONLog は JSON オブジェクト1つからなる行をデコードします（JSON でなければ nil）
+func parseJSONLog(text string) map[string]interface{} {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &obj); err != nil {
+		return nil
+	}
+	return obj
+}
+
+// matches はログ本文が検索条件を満たすかを返します
+func (q logQuery) matches(text string) bool {
+	if q.Pattern != nil && !q.Pattern.MatchString(text) {
+		return false
+	}
+	if len(q.Fields) == 0 {
+		return true
+	}
+	obj := parseJSONLog(text)
+	if obj == nil {
+		return false
+	}
+	for _, c := range q.Fields {
+		if !c.matches(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// logSearchRequest は logs search の引数を解釈した結果です
+type logSearchRequest struct {
+	Query     logQuery
+	Selectors []targetSelector
+	Since     string
+	Until     string
+	Before    int
+	After     int
+}
+
+// parseLogSearchArgs は logs search <pattern> [selector...] [options] を解釈します。
+// pattern は key=value ならフィールド条件、それ以外は正規表現です。
+// pattern の後ろの key=value は選択子のキー（label, status など）以外ならフィールド条件として追加します。
+func parseLogSearchArgs(args []string) (logSearchRequest, error) {
+	var (
+		req        logSearchRequest
+		positional []string
+		wheres     []string
+		ignoreCase bool
+	)
+	intValue := func(opt, value string) (int, error) {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf(i18n.T("logsearch.invalid_number"), opt, value)
+		}
+		return n, nil
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		opt, ok := findOption(logSearchOptions, name)
+		if !ok {
+			return req, fmt.Errorf(i18n.T("logsearch.unknown_option"), arg)
+		}
+		if opt.Type != valueNone && !hasValue {
+			if i+1 >= len(args) {
+				return req, fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			i++
+			value = args[i]
+		}
+		var err error
+		switch opt.Name {
+		case "--since":
+			req.Since = value
+		case "--until":
+			req.Until = value
+		case "--project":
+			req.Selectors = append(req.Selectors, targetSelector{Kind: selectProject, Value: value, Raw: "project=" + value})
+		case "--label":
+			req.Selectors = append(req.Selectors, targetSelector{Kind: selectLabel, Value: value, Raw: "label=" + value})
+		case "--where":
+			wheres = append(wheres, value)
+		case "--context":
+			if req.Before, err = intValue(arg, value); err == nil {
+				req.After = req.Before
+			}
+		case "--before-context":
+			req.Before, err = intValue(arg, value)
+		case "--after-context":
+			req.After, err = intValue(arg, value)
+		case "--ignore-case":
+			ignoreCase = true
+		}
+		if err != nil {
+			return req, err
+		}
+	}
+	if len(positional) == 0 {
+		return req, fmt.Errorf(i18n.T("logsearch.usage"))
+	}
+
+	addCondition := func(text string) error {
+		cond, ok, err := parseFieldCondition(text, ignoreCase)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf(i18n.T("logsearch.invalid_where"), text)
+		}
+		req.Query.Fields = append(req.Query.Fields, cond)
+		return nil
+	}
+
+	pattern := positional[0]
+	if cond, ok, err := parseFieldCondition(pattern, ignoreCase); err != nil {
+		return req, err
+	} else if ok {
+		req.Query.Fields = append(req.Query.Fields, cond)
+	} else {
+		expr := pattern
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return req, fmt.Errorf(i18n.T("selector.invalid_regex"), pattern, err)
+		}
+		req.Query.Pattern = re
+	}
+	for _, arg := range positional[1:] {
+		if !isSelectorArg(arg) && fieldConditionPattern.MatchString(arg) {
+			if err := addCondition(arg); err != nil {
+				return req, err
+			}
+			continue
+		}
+		sel, err := parseTargetSelector(arg)
+		if err != nil {
+			return req, err
+		}
+		req.Selectors = append(req.Selectors, sel)
+	}
+	for _, w := range wheres {
+		if err := addCondition(w); err != nil {
+			return req, err
+		}
+	}
+	return req, nil
+}
+
+// logMatch は1コンテナ分の検索結果です
+type logMatch struct {
+	Lines   []logLine
+	Matched []bool
+	Count   int
+}
+
+// searchLogLines は一致した行とその前後の行を選び出します
+func searchLogLines(lines []logLine, query logQuery, before, after int) logMatch {
+	var result logMatch
+	keep := make([]bool, len(lines))
+	matched := make([]bool, len(lines))
+	for i, l := range lines {
+		if !query.matches(l.Text) {
+			continue
+		}
+		result.Count++
+		matched[i] = true
+		for j := i - before; j <= i+after; j++ {
+			if j >= 0 && j < len(lines) {
+				keep[j] = true
+			}
+		}
+	}
+	for i, l := range lines {
+		if keep[i] {
+			if len(result.Lines) > 0 && i > 0 && !keep[i-1] {
+				// 連続しない箇所の区切り
+				result.Lines = append(result.Lines, logLine{Source: -1})
+				result.Matched = append(result.Matched, false)
+			}
+			result.Lines = append(result.Lines, l)
+			result.Matched = append(result.Matched, matched[i])
+		}
+	}
+	return result
+}
+
+// runLogSearch は logs search を実行します。選択したコンテナのログ（既定は直近1時間）を
+// 並行して取得し、一致した行を前後の行と一緒にコンテナごとに表示します。
+func (s *Shell) runLogSearch(parsedCmd *parser.ParsedCommand) error {
+	req, err := parseLogSearchArgs(parsedCmd.RawArgs[1:])
+	if err != nil {
+		return err
+	}
+	targets, err := s.resolveTargets(req.Selectors, false)
+	if err != nil {
+		return err
+	}
+	since := req.Since
+	if since == "" {
+		since = defaultLogSearchSince
+	}
+	fmt.Printf(i18n.T("logsearch.searching")+"\n", len(targets), since)
+
+	logArgs := []string{"logs", "--timestamps", "--since", since}
+	if req.Until != "" {
+		logArgs = append(logArgs, "--until", req.Until)
+	}
+	results := runOnTargets(targets, func(t dockerTarget) targetResult {
+		var stderr strings.Builder
+		cmd := exec.Command("docker", append(logArgs, t.Name)...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return targetResult{Target: t, Output: stderr.String(), Err: err}
+		}
+		// docker logs はコンテナの stderr を stderr に出すため、両方を検索対象にする
+		return targetResult{Target: t, Output: string(out) + stderr.String()}
+	}, nil)
+
+	total, containers := 0, 0
+	for i, r := range results {
+		if r.Err != nil {
+			fmt.Printf("❌ %s: %s\n", r.Target.Name, firstLine(strings.TrimSpace(r.Output), r.Err))
+			continue
+		}
+		var lines []logLine
+		for _, raw := range strings.Split(strings.TrimRight(r.Output, "\n"), "\n") {
+			if raw == "" {
+				continue
+			}
+			t, text := splitLogTimestamp(raw)
+			lines = append(lines, logLine{Time: t, Text: text})
+		}
+		sortLogLines(lines)
+		match := searchLogLines(lines, req.Query, req.Before, req.After)
+		if match.Count == 0 {
+			continue
+		}
+		total += match.Count
+		containers++
+		header := fmt.Sprintf("==> %s (%d) <==", r.Target.Name, match.Count)
+		fmt.Printf("\n%s\n", themes.Colorize(header, themes.GetServiceColor(s.config.Theme, i)))
+		for j, l := range match.Lines {
+			if l.Source < 0 {
+				fmt.Println("--")
+				continue
+			}
+			marker, text := " ", l.Text
+			if match.Matched[j] {
+				marker = ">"
+				text = highlightMatches(text, req.Query.Pattern)
+			}
+			if l.Time.IsZero() {
+				fmt.Printf("%s %s\n", marker, text)
+			} else {
+				fmt.Printf("%s %s %s\n", marker, l.Time.Local().Format("2006-01-02T15:04:05.000"), text)
+			}
+		}
+	}
+	fmt.Printf("\n"+i18n.T("logsearch.summary")+"\n", total, containers, len(targets))
+	return nil
+}
+
+// highlightMatches は正規表現に一致した部分を強調します
+func highlightMatches(text string, re *regexp.Regexp) string {
+	if re == nil {
+		return text
+	}
+	return re.ReplaceAllStringFunc(text, func(m string) string {
+		if m == "" {
+			return m
+		}
+		return themes.Colorize(m, "red")
+	})
+}
+
+// completeLogsArguments は logs の補完です（logs search の後ろは検索用のオプションと選択子）
+func (s *Shell) completeLogsArguments(words []string, currentArg, beforeCursor string) []Suggest {
+	// words[1] が入力済みの "search" なら検索の引数を補完
+	if len(words) > 2 || (len(words) == 2 && strings.HasSuffix(beforeCursor, " ")) {
+		if words[1] == "search" {
+			if suggests, ok := s.completeOptions(logSearchOptions, words, currentArg, beforeCursor, ""); ok {
+				return suggests
+			}
+			return s.completeDockerContainers(currentArg, false)
+		}
+	}
+	if suggests, ok := s.completeOptions(containerLogsOptions, words, currentArg, beforeCursor, ""); ok {
+		return suggests
+	}
+	suggests := s.completeDockerContainers(currentArg, false)
+	if len(words) <= 2 {
+		suggests = append(filterFuzzy([]Suggest{{Text: "search", Description: i18n.T("completion.descriptions.logs_search")}}, currentArg, false), suggests...)
+	}
+	return suggests
+}
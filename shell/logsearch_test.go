@@ -0,0 +1,189 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldCondition(t *testing.T) {
+	tests := []struct {
+		text    string
+		wantOK  bool
+		wantKey string
+		wantOp  string
+		wantVal string
+		wantErr bool
+	}{
+		{text: "level=error", wantOK: true, wantKey: "level", wantOp: "=", wantVal: "error"},
+		{text: "http.status!=200", wantOK: true, wantKey: "http.status", wantOp: "!=", wantVal: "200"},
+		{text: "msg~time(out)?", wantOK: true, wantKey: "msg", wantOp: "~", wantVal: "time(out)?"},
+		{text: "@timestamp=x", wantOK: true, wantKey: "@timestamp", wantOp: "=", wantVal: "x"},
+		{text: "level=", wantOK: true, wantKey: "level", wantOp: "=", wantVal: ""},
+		{text: "msg~(", wantOK: true, wantErr: true},
+		{text: "timeout", wantOK: false},
+		{text: "=error", wantOK: false},
+		{text: "1level=error", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			cond, ok, err := parseFieldCondition(tt.text, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFieldCondition(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("parseFieldCondition(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			}
+			if !ok || tt.wantErr {
+				return
+			}
+			if cond.Key != tt.wantKey || cond.Op != tt.wantOp || cond.Value != tt.wantVal {
+				t.Errorf("parseFieldCondition(%q) = %s %s %q, want %s %s %q", tt.text, cond.Key, cond.Op, cond.Value, tt.wantKey, tt.wantOp, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestLogQueryMatches(t *testing.T) {
+	lines := map[string]string{
+		"error":   `{"level":"error","msg":"upstream timeout","http":{"status":504}}`,
+		"ERROR":   `{"level":"ERROR","msg":"disk full"}`,
+		"info":    `{"level":"info","msg":"ok","http":{"status":200}}`,
+		"dotted":  `{"http.status":500,"msg":"flat key"}`,
+		"nolevel": `{"msg":"no level"}`,
+		"plain":   `level=error connection refused`,
+	}
+	tests := []struct {
+		name       string
+		args       []string
+		ignoreCase bool
+		want       []string // matching keys of lines
+	}{
+		{"field equals is case-insensitive and skips non-JSON lines", []string{"level=error"}, false, []string{"ERROR", "error"}},
+		{"not equals matches missing keys", []string{"level!=error"}, false, []string{"dotted", "info", "nolevel"}},
+		{"nested key", []string{"http.status=504"}, false, []string{"error"}},
+		{"flat dotted key wins", []string{"http.status=500"}, false, []string{"dotted"}},
+		{"regex field", []string{"msg~time"}, false, []string{"error"}},
+		{"regex field ignore case", []string{"msg~DISK"}, true, []string{"ERROR"}},
+		{"line regex", []string{"refused|full"}, false, []string{"ERROR", "plain"}},
+		{"regex and field together", []string{"timeout", "level=error"}, false, []string{"error"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.ignoreCase {
+				args = append([]string{"-i"}, args...)
+			}
+			req, err := parseLogSearchArgs(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, key := range []string{"ERROR", "dotted", "error", "info", "nolevel", "plain"} {
+				if req.Query.matches(lines[key]) {
+					got = append(got, key)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%v matched %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLogSearchArgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantFields    int
+		wantPattern   string
+		wantSelectors []string
+		wantSince     string
+		wantBefore    int
+		wantAfter     int
+		wantErr       bool
+	}{
+		{name: "pattern only", args: []string{"timeout"}, wantPattern: "timeout"},
+		{name: "field pattern", args: []string{"level=error"}, wantFields: 1},
+		{
+			name:          "selectors and extra conditions",
+			args:          []string{"timeout", "web-*", "status=running", "level=error", "--where", "msg~db"},
+			wantPattern:   "timeout",
+			wantFields:    2,
+			wantSelectors: []string{"web-*", "status=running"},
+		},
+		{
+			name:          "options",
+			args:          []string{"-C", "2", "-A=5", "--since", "30m", "--project", "shop", "--label=env=dev", "x"},
+			wantPattern:   "x",
+			wantSelectors: []string{"project=shop", "label=env=dev"},
+			wantSince:     "30m",
+			wantBefore:    2,
+			wantAfter:     5,
+		},
+		{name: "ignore case", args: []string{"-i", "Timeout"}, wantPattern: "(?i)Timeout"},
+		{name: "no pattern", args: []string{"--since", "1h"}, wantErr: true},
+		{name: "unknown option", args: []string{"--bogus", "x"}, wantErr: true},
+		{name: "missing value", args: []string{"x", "--since"}, wantErr: true},
+		{name: "negative context", args: []string{"-C", "-1", "x"}, wantErr: true},
+		{name: "invalid regex", args: []string{"("}, wantErr: true},
+		{name: "invalid where", args: []string{"x", "--where", "nonsense"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := parseLogSearchArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLogSearchArgs(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			pattern := ""
+			if req.Query.Pattern != nil {
+				pattern = req.Query.Pattern.String()
+			}
+			if pattern != tt.wantPattern {
+				t.Errorf("pattern = %q, want %q", pattern, tt.wantPattern)
+			}
+			if len(req.Query.Fields) != tt.wantFields {
+				t.Errorf("fields = %v, want %d", req.Query.Fields, tt.wantFields)
+			}
+			var selectors []string
+			for _, sel := range req.Selectors {
+				selectors = append(selectors, sel.Raw)
+			}
+			if !reflect.DeepEqual(selectors, tt.wantSelectors) {
+				t.Errorf("selectors = %v, want %v", selectors, tt.wantSelectors)
+			}
+			if req.Since != tt.wantSince || req.Before != tt.wantBefore || req.After != tt.wantAfter {
+				t.Errorf("since/before/after = %q/%d/%d, want %q/%d/%d", req.Since, req.Before, req.After, tt.wantSince, tt.wantBefore, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestSearchLogLines(t *testing.T) {
+	var lines []logLine
+	for _, text := range []string{"a", "b", "match 1", "c", "d", "e", "match 2", "f"} {
+		lines = append(lines, logLine{Text: text})
+	}
+	req, err := parseLogSearchArgs([]string{"match", "-C", "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := searchLogLines(lines, req.Query, req.Before, req.After)
+	if result.Count != 2 {
+		t.Errorf("Count = %d, want 2", result.Count)
+	}
+	var got []string
+	for _, l := range result.Lines {
+		if l.Source < 0 {
+			got = append(got, "--")
+			continue
+		}
+		got = append(got, l.Text)
+	}
+	want := []string{"b", "match 1", "c", "--", "e", "match 2", "f"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"docsh/i18n"
+	"docsh/tui"
+)
+
+// defaultMetricsListen は metrics serve の既定の待ち受けアドレスです（ローカルホストのみ）
+const defaultMetricsListen = "127.0.0.1:9339"
+
+// metricsOptions は metrics serve が受け付けるオプションです
+var metricsOptions = []optionSpec{
+	{Name: "--listen", Type: valueString, DescKey: "help.option_descriptions.listen"},
+	{Name: "--source", Type: valueEnum, Enum: tui.StatsSources, DescKey: "help.option_descriptions.stats_source"},
+}
+
+// handleMetricsCommand は metrics のサブコマンドを処理します
+func (s *Shell) handleMetricsCommand(args []string) error {
+	if len(args) == 0 || args[0] != "serve" {
+		return fmt.Errorf(i18n.T("metrics.usage"))
+	}
+	return s.serveMetrics(args[1:])
+}
+
+// serveMetrics はコンテナの統計を Prometheus のテキスト形式で公開し、
+// SIGINT/SIGTERM か、端末からの exit または q + Enter で停止するまで待ち受けます
+func (s *Shell) serveMetrics(args []string) error {
+	listen := defaultMetricsListen
+	source := tui.StatsSourceAuto
+	if s.config != nil && s.config.MonitorSource != "" {
+		source = s.config.MonitorSource
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		opt, ok := findOption(metricsOptions, name)
+		if !ok {
+			return fmt.Errorf(i18n.T("metrics.unknown_option")+"\n"+i18n.T("metrics.usage"), arg)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			i++
+			value = args[i]
+		}
+		switch opt.Name {
+		case "--listen":
+			listen = value
+		case "--source":
+			if !containsString(opt.Enum, value) {
+				return fmt.Errorf(i18n.T("monitor.invalid_source"), value, strings.Join(opt.Enum, ", "))
+			}
+			source = value
+		}
+	}
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+	exporter, err := tui.NewMetricsExporter(source)
+	if err != nil {
+		ln.Close()
+		return err
+	}
+	defer exporter.Close()
+
+	srv := &http.Server{Handler: exporter, ReadHeaderTimeout: 10 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.Serve(ln) }()
+
+	fmt.Printf(i18n.T("metrics.started")+"\n", ln.Addr(), exporter.Source())
+	if !isLoopback(ln.Addr()) {
+		fmt.Println(i18n.T("metrics.not_loopback"))
+	}
+	fmt.Println(i18n.T("metrics.tip"))
+
+	ctx, stop := serviceContext()
+	defer stop()
+	terminationChan := make(chan string, 1)
+	if stdinIsTerminal() {
+		go watchForLogControl(ctx, terminationChan, make(chan struct{}, 1))
+	}
+
+	shutdown := func() {
+		stop()
+		timeout, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		srv.Shutdown(timeout)
+	}
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+		shutdown()
+		fmt.Println(i18n.T("app.command_stopped_signal"))
+		return nil
+	case <-terminationChan:
+		shutdown()
+		fmt.Println(i18n.T("app.command_stopped"))
+		return nil
+	}
+}
+
+// isLoopback は待ち受けアドレスがローカルホストに限られるか判定します
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
+
+// completeMetricsCommand は metrics のサブコマンドと serve のオプションを補完します
+func (s *Shell) completeMetricsCommand(words []string, currentArg, beforeCursor string) []Suggest {
+	if !containsString(words[1:], "serve") || (len(words) == 2 && !strings.HasSuffix(beforeCursor, " ")) {
+		return filterFuzzy([]Suggest{{Text: "serve", Description: i18n.T("metrics.serve_description")}}, currentArg, false)
+	}
+	if suggests, ok := s.completeOptions(metricsOptions, words, currentArg, beforeCursor, ""); ok {
+		return suggests
+	}
+	return []Suggest{}
+}
@@ -0,0 +1,174 @@
+package shell
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"docsh/i18n"
+	"docsh/internal/alert"
+	"docsh/tui"
+)
+
+// watchOptions は watch と htop に共通のサンプリングのオプションです
+var watchOptions = []optionSpec{
+	{Name: "--interval", Type: valueDuration, DescKey: "help.option_descriptions.interval"},
+	{Name: "--retention", Type: valueDuration, DescKey: "help.option_descriptions.retention"},
+	{Name: "--source", Type: valueEnum, Enum: tui.StatsSources, DescKey: "help.option_descriptions.stats_source"},
+}
+
+// htopOptions は htop が受け付けるオプションです（記録と再生を含む）
+var htopOptions = append(append([]optionSpec{}, watchOptions...),
+	optionSpec{Name: "--record", Type: valueString, DescKey: "help.option_descriptions.record"},
+	optionSpec{Name: "--replay", Type: valueString, DescKey: "help.option_descriptions.replay"},
+	optionSpec{Name: "--speed", Type: valueString, DescKey: "help.option_descriptions.speed"},
+)
+
+// parseMonitorOptions は htop / watch の引数を specs に従って解釈します。指定がなければ設定ファイルの値を使います。
+// section はエラーメッセージのロケールセクション（monitor または watch）です
+func (s *Shell) parseMonitorOptions(args []string, specs []optionSpec, section string) (tui.MonitorOptions, error) {
+	opts := tui.MonitorOptions{}
+	if s.config != nil {
+		opts.Interval = s.config.MonitorInterval
+		opts.Retention = s.config.MonitorRetention
+		opts.Source = s.config.MonitorSource
+		opts.Alerts = s.config.Alerts
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(arg, "=")
+		opt, ok := findOption(specs, name)
+		if !ok {
+			return opts, fmt.Errorf(i18n.T(section+".unknown_option")+"\n"+i18n.T(section+".usage"), arg)
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			i++
+			value = args[i]
+		}
+		switch opt.Name {
+		case "--source":
+			if !containsString(opt.Enum, value) {
+				return opts, fmt.Errorf(i18n.T("monitor.invalid_source"), value, strings.Join(opt.Enum, ", "))
+			}
+			opts.Source = value
+			continue
+		case "--record":
+			opts.Record = value
+			continue
+		case "--replay":
+			opts.Replay = value
+			continue
+		case "--speed":
+			speed, err := strconv.ParseFloat(value, 64)
+			if err != nil || speed <= 0 {
+				return opts, fmt.Errorf(i18n.T("monitor.invalid_speed"), value)
+			}
+			opts.Speed = speed
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return opts, fmt.Errorf(i18n.T("monitor.invalid_duration"), opt.Name, value)
+		}
+		switch opt.Name {
+		case "--interval":
+			opts.Interval = d
+		case "--retention":
+			opts.Retention = d
+		}
+	}
+	return opts, nil
+}
+
+// runAlertWatch は UI なしでアラートルールを評価し、発火と解消を表示し続けます。
+// 端末では Enter で発火中のアラートを一覧し、exit + Enter で終了します。
+// SIGINT/SIGTERM でも終了し、親プロセスが変わっても動き続けます。
+func (s *Shell) runAlertWatch(args []string) error {
+	opts, err := s.parseMonitorOptions(args, watchOptions, "watch")
+	if err != nil {
+		return err
+	}
+	if len(opts.Alerts.Rules) == 0 {
+		return fmt.Errorf(i18n.T("watch.no_rules"))
+	}
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+	w, err := tui.NewWatcher(opts)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	fmt.Printf(i18n.T("watch.started")+"\n", len(opts.Alerts.Rules), w.Source(), w.Interval())
+	for _, r := range opts.Alerts.Rules {
+		line := "  • " + r.Describe()
+		if r.Name != "" {
+			line = "  • " + r.Name + ": " + r.Describe()
+		}
+		if r.Container != "" {
+			line += fmt.Sprintf(" (%s)", r.Container)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println(i18n.T("watch.tip"))
+
+	ctx, stop := serviceContext()
+	defer stop()
+	terminationChan := make(chan string, 1)
+	enterChan := make(chan struct{}, 1)
+	if stdinIsTerminal() {
+		go watchForLogControl(ctx, terminationChan, enterChan)
+	}
+
+	var lastErr string
+	step := func() {
+		events, err := w.Step()
+		for _, e := range events {
+			printAlertEvent(e)
+		}
+		// 同じエラーは繰り返し表示しない
+		msg := ""
+		if err != nil {
+			if msg = err.Error(); msg != lastErr {
+				fmt.Printf("❌ %s\n", msg)
+			}
+		}
+		lastErr = msg
+	}
+	step()
+	ticker := time.NewTicker(w.Interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			step()
+		case <-enterChan:
+			active := w.Active()
+			fmt.Printf(i18n.T("watch.summary")+"\n", time.Now().Format("15:04:05"), w.Containers(), len(active))
+			for _, e := range active {
+				fmt.Printf("  🚨 %s\n", e.Message())
+			}
+		case <-ctx.Done():
+			fmt.Println(i18n.T("app.command_stopped_signal"))
+			return nil
+		case <-terminationChan:
+			stop()
+			fmt.Println(i18n.T("app.command_stopped"))
+			return nil
+		}
+	}
+}
+
+// printAlertEvent はアラートの発火・解消を1行で表示します
+func printAlertEvent(e alert.Event) {
+	key := "watch.resolved"
+	if e.Firing {
+		key = "watch.firing"
+	}
+	fmt.Printf(i18n.T(key)+"\n", e.At.Format("15:04:05"), e.Message())
+}
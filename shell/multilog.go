@@ -0,0 +1,400 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"docsh/i18n"
+	"docsh/themes"
+)
+
+// logSource は多重化して表示するログの取得元です
+type logSource struct {
+	Label     string // 行頭に表示する名前（サービス名やコンテナ名）
+	Container string
+	Color     int // テーマのサービス色の番号（選び方が変わっても同じサービスは同じ色にする）
+}
+
+// logLine は取得元から読み取った1行です
+type logLine struct {
+	Source  int
+	Time    time.Time // docker logs --timestamps の時刻（読めなければゼロ値）
+	Text    string
+	arrived time.Time
+}
+
+// muxLogOptions は多重化ログの表示オプションです
+type muxLogOptions struct {
+	Follow     bool
+	Timestamps bool
+	Since      string
+	Until      string
+	Tail       string
+	Grep       *regexp.Regexp
+}
+
+// muxLogsOptions は多重化ログが受け付けるオプションです（docker logs のオプションと --grep）
+var muxLogsOptions = append(append([]optionSpec{}, dockerLogsOptions...),
+	optionSpec{Name: "--grep", Type: valueString, DescKey: "help.option_descriptions.grep"})
+
+const (
+	// logReorderWindow は追跡中に複数の取得元の行を時刻順へ並べ直すために保留する時間です
+	logReorderWindow = 250 * time.Millisecond
+	// maxPausedLogLines は一時停止中に溜めておく行数の上限です（超えた分は古い順に捨てる）
+	maxPausedLogLines = 10000
+)
+
+// parseMuxLogOptions はオプションを解釈し、残りの位置引数を返します
+func parseMuxLogOptions(args []string, options []optionSpec) (muxLogOptions, []string, error) {
+	var (
+		opts muxLogOptions
+		rest []string
+	)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			rest = append(rest, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg, "=")
+		opt, ok := findOption(options, name)
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+		if opt.Type != valueNone && !hasValue {
+			if i+1 >= len(args) {
+				return opts, nil, fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			i++
+			value = args[i]
+		}
+		switch opt.Name {
+		case "--follow":
+			opts.Follow = true
+		case "--timestamps":
+			opts.Timestamps = true
+		case "--since":
+			opts.Since = value
+		case "--until":
+			opts.Until = value
+		case "--tail":
+			opts.Tail = value
+		case "--grep":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return opts, nil, fmt.Errorf(i18n.T("selector.invalid_regex"), value, err)
+			}
+			opts.Grep = re
+		default:
+			// --label などの選択用オプションは呼び出し側で扱う
+			rest = append(rest, arg)
+			if opt.Type != valueNone && !hasValue {
+				rest = append(rest, value)
+			}
+		}
+	}
+	return opts, rest, nil
+}
+
+// dockerArgs は取得元1つ分の docker logs の引数を返します（並べ替えのため常に --timestamps を付ける）
+func (o muxLogOptions) dockerArgs(container string) []string {
+	args := []string{"logs", "--timestamps"}
+	if o.Follow {
+		args = append(args, "--follow")
+	}
+	if o.Since != "" {
+		args = append(args, "--since", o.Since)
+	}
+	if o.Until != "" {
+		args = append(args, "--until", o.Until)
+	}
+	if o.Tail != "" {
+		args = append(args, "--tail", o.Tail)
+	}
+	return append(args, container)
+}
+
+// splitLogTimestamp は docker logs --timestamps の行を時刻と本文に分けます
+func splitLogTimestamp(line string) (time.Time, string) {
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+			return t, line[i+1:]
+		}
+	}
+	return time.Time{}, line
+}
+
+// logPrinter は行頭にサービスごとの色付きの名前を付けて出力します
+type logPrinter struct {
+	prefixes   []string
+	timestamps bool
+	grep       *regexp.Regexp
+}
+
+func newLogPrinter(theme string, sources []logSource, opts muxLogOptions) *logPrinter {
+	width := 0
+	for _, src := range sources {
+		if len(src.Label) > width {
+			width = len(src.Label)
+		}
+	}
+	p := &logPrinter{timestamps: opts.Timestamps, grep: opts.Grep}
+	for _, src := range sources {
+		prefix := fmt.Sprintf("%-*s |", width, src.Label)
+		p.prefixes = append(p.prefixes, themes.Colorize(prefix, themes.GetServiceColor(theme, src.Color)))
+	}
+	return p
+}
+
+func (p *logPrinter) print(l logLine) {
+	if p.grep != nil && !p.grep.MatchString(l.Text) {
+		return
+	}
+	if p.timestamps && !l.Time.IsZero() {
+		fmt.Printf("%s %s %s\n", p.prefixes[l.Source], l.Time.Local().Format("2006-01-02T15:04:05.000"), l.Text)
+		return
+	}
+	fmt.Printf("%s %s\n", p.prefixes[l.Source], l.Text)
+}
+
+// sortLogLines は行を時刻順に並べます（同時刻や時刻なしは読み取り順を保つ）
+func sortLogLines(lines []logLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		if lines[i].Time.IsZero() || lines[j].Time.IsZero() {
+			return false
+		}
+		return lines[i].Time.Before(lines[j].Time)
+	})
+}
+
+// readLogLines は r から1行ずつ読み取り out に送ります（ctx が終わると止める）
+func readLogLines(ctx context.Context, source int, r io.Reader, out chan<- logLine) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		t, text := splitLogTimestamp(scanner.Text())
+		select {
+		case out <- logLine{Source: source, Time: t, Text: text, arrived: time.Now()}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runMultiplexedLogs は複数の取得元のログを1つにまとめて表示します
+func (s *Shell) runMultiplexedLogs(sources []logSource, opts muxLogOptions) error {
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+	printer := newLogPrinter(s.config.Theme, sources, opts)
+	if opts.Follow {
+		return s.followMultiplexedLogs(sources, opts, printer)
+	}
+
+	// 追跡しない場合は全件を取得してから時刻順に並べて表示する
+	var (
+		mu    sync.Mutex
+		lines []logLine
+		errs  []string
+		wg    sync.WaitGroup
+	)
+	sem := make(chan struct{}, maxConcurrentTargets)
+	for i, src := range sources {
+		wg.Add(1)
+		go func(i int, src logSource) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			var stderr strings.Builder
+			cmd := exec.Command("docker", opts.dockerArgs(src.Container)...)
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("❌ %s: %s", src.Label, firstLine(strings.TrimSpace(stderr.String()), err)))
+				return
+			}
+			for _, raw := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+				if raw == "" {
+					continue
+				}
+				t, text := splitLogTimestamp(raw)
+				lines = append(lines, logLine{Source: i, Time: t, Text: text})
+			}
+		}(i, src)
+	}
+	wg.Wait()
+
+	sortLogLines(lines)
+	for _, l := range lines {
+		printer.print(l)
+	}
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+	return nil
+}
+
+// followMultiplexedLogs は全取得元を追跡し、短い保留時間の中で時刻順に並べ直して表示します。
+// Enter で一時停止/再開、exit または q + Enter で終了します。
+func (s *Shell) followMultiplexedLogs(sources []logSource, opts muxLogOptions, printer *logPrinter) error {
+	fmt.Println(i18n.T("multilog.follow_tip"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lines := make(chan logLine, 256)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		cmd := exec.CommandContext(ctx, "docker", opts.dockerArgs(src.Container)...)
+		setShellProcessGroup(cmd)
+		pr, pw := io.Pipe()
+		cmd.Stdout = pw
+		cmd.Stderr = pw
+		if err := cmd.Start(); err != nil {
+			fmt.Printf("❌ %s: %v\n", src.Label, err)
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			readLogLines(ctx, i, pr, lines)
+		}(i)
+		go func(cmd *exec.Cmd) {
+			cmd.Wait()
+			pw.Close()
+		}(cmd)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	terminationChan := make(chan string, 5)
+	go s.watchForSignals(ctx, terminationChan)
+	pauseChan := make(chan struct{}, 1)
+	go watchForLogControl(ctx, terminationChan, pauseChan)
+
+	var (
+		pending []logLine
+		paused  bool
+		dropped int
+	)
+	flush := func(all bool) {
+		if paused {
+			return
+		}
+		cutoff := time.Now().Add(-logReorderWindow)
+		var ready, later []logLine
+		for _, l := range pending {
+			if all || l.arrived.Before(cutoff) {
+				ready = append(ready, l)
+			} else {
+				later = append(later, l)
+			}
+		}
+		sortLogLines(ready)
+		for _, l := range ready {
+			printer.print(l)
+		}
+		pending = later
+	}
+
+	ticker := time.NewTicker(logReorderWindow / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case l := <-lines:
+			pending = append(pending, l)
+			if paused && len(pending) > maxPausedLogLines {
+				dropped += len(pending) - maxPausedLogLines
+				pending = pending[len(pending)-maxPausedLogLines:]
+			}
+		case <-ticker.C:
+			flush(false)
+		case <-pauseChan:
+			paused = !paused
+			if paused {
+				fmt.Println(i18n.T("multilog.paused"))
+				continue
+			}
+			fmt.Printf(i18n.T("multilog.resumed")+"\n", len(pending), dropped)
+			dropped = 0
+			flush(true)
+		case <-done:
+			// 読み取りが終わった後に残っている行も表示する
+			for len(lines) > 0 {
+				pending = append(pending, <-lines)
+			}
+			paused = false
+			flush(true)
+			fmt.Println(i18n.T("app.command_completed"))
+			return nil
+		case reason := <-terminationChan:
+			cancel()
+			paused = false
+			flush(true)
+			if strings.HasPrefix(reason, "signal") {
+				fmt.Println(i18n.T("app.command_stopped_signal"))
+			} else {
+				fmt.Println(i18n.T("app.command_stopped"))
+			}
+			return nil
+		}
+	}
+}
+
+// watchForLogControl は追跡中の標準入力を監視します。空の Enter で一時停止/再開、exit/quit/q で終了します。
+func watchForLogControl(ctx context.Context, terminationChan chan<- string, pauseChan chan<- struct{}) {
+	inputBuffer := ""
+	buffer := make([]byte, 1)
+	lastCR := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		n, err := os.Stdin.Read(buffer)
+		if err != nil {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		char := buffer[0]
+		// CRLF は1回の Enter として扱う
+		crlf := lastCR && char == '\n'
+		lastCR = char == '\r'
+		switch {
+		case crlf:
+		case char == '\n' || char == '\r':
+			switch strings.TrimSpace(inputBuffer) {
+			case "":
+				select {
+				case pauseChan <- struct{}{}:
+				default:
+				}
+			case "exit", "quit", "q", "stop":
+				terminationChan <- "stdin_exit"
+				return
+			}
+			inputBuffer = ""
+		case char >= ' ' && char <= '~':
+			inputBuffer += string(char)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package shell
+
+import (
+	"sort"
+	"strings"
+
+	"docsh/i18n"
+	"docsh/internal/engine"
+	"docsh/internal/parser"
+)
+
+// valueKind はオプション値の種類です（補完とヘルプに使用）
+type valueKind int
+
+const (
+	valueNone valueKind = iota // 値を取らないフラグ
+	valueContainer
+	valueImage
+	valueService
+	valueProject
+	valueDuration
+	valueInt
+	valueEnum
+	valueString
+)
+
+// parseValueKind は mappings.yaml の type 文字列を valueKind に変換します
+func parseValueKind(name string) valueKind {
+	switch name {
+	case "container":
+		return valueContainer
+	case "image":
+		return valueImage
+	case "service":
+		return valueService
+	case "project":
+		return valueProject
+	case "duration":
+		return valueDuration
+	case "int":
+		return valueInt
+	case "enum":
+		return valueEnum
+	case "", "flag", "bool":
+		return valueNone
+	default:
+		return valueString
+	}
+}
+
+// description は現在の言語でのオプションの説明を返します
+func (o optionSpec) description() string {
+	if o.DescKey != "" {
+		return i18n.T(o.DescKey)
+	}
+	return o.Description
+}
+
+// placeholder はヘルプ表示用の値プレースホルダーを返します
+func (o optionSpec) placeholder() string {
+	switch o.Type {
+	case valueNone:
+		return ""
+	case valueContainer:
+		return "<container>"
+	case valueImage:
+		return "<image>"
+	case valueService:
+		return "<service>"
+	case valueProject:
+		return "<project>"
+	case valueDuration:
+		return "<duration>"
+	case valueInt:
+		return "N"
+	case valueEnum:
+		return strings.Join(o.Enum, "|")
+	default:
+		return "<value>"
+	}
+}
+
+// matches はトークン（--tail や -n）がこのオプションを指すかを返します
+func (o optionSpec) matches(token string) bool {
+	return token != "" && (token == o.Name || token == o.Short)
+}
+
+// key は ParsedCommand.Options でのキー（先頭のハイフンを除いた名前）を返します
+func (o optionSpec) key(token string) string {
+	return strings.TrimLeft(token, "-")
+}
+
+// mappingOptionSpecs はマッピングに定義されたオプションを optionSpec に変換します
+func mappingOptionSpecs(m *engine.CommandMapping) []optionSpec {
+	lang := i18n.GetCurrentLanguage()
+	specs := make([]optionSpec, 0, len(m.Options))
+	for i := range m.Options {
+		opt := &m.Options[i]
+		specs = append(specs, optionSpec{
+			Name:        opt.Name,
+			Short:       opt.Short,
+			Type:        parseValueKind(opt.Type),
+			Enum:        opt.Values,
+			Description: opt.DescriptionFor(lang),
+		})
+	}
+	return specs
+}
+
+// allOptions は内蔵の定義とマッピング由来のオプションを重複なしで返します
+func (c *commandSpec) allOptions() []optionSpec {
+	options := append([]optionSpec{}, c.Options...)
+	seen := map[string]bool{}
+	mark := func(opt optionSpec) {
+		for _, flag := range nonEmpty(opt.Name, opt.Short) {
+			seen[flag] = true
+		}
+	}
+	for _, opt := range options {
+		mark(opt)
+	}
+	for _, m := range c.Mappings {
+		for _, opt := range mappingOptionSpecs(m) {
+			if seen[opt.Name] || seen[opt.Short] {
+				continue
+			}
+			mark(opt)
+			options = append(options, opt)
+		}
+	}
+	return options
+}
+
+// findOption はトークンに対応するオプション定義を返します
+func findOption(options []optionSpec, token string) (optionSpec, bool) {
+	for _, opt := range options {
+		if opt.matches(token) {
+			return opt, true
+		}
+	}
+	return optionSpec{}, false
+}
+
+// bindOptionValues は値を取るオプション（--tail 10 など）の値を位置引数から取り出して Options に設定します。
+// パーサーはオプションが値を取るかを知らないため、レジストリのメタデータで補正します。
+func bindOptionValues(cmd *parser.ParsedCommand, options []optionSpec) {
+	if len(options) == 0 || len(cmd.RawArgs) == 0 {
+		return
+	}
+	var args []string
+	j := 0
+	for i := 0; i < len(cmd.RawArgs); i++ {
+		tok := cmd.RawArgs[i]
+		if j < len(cmd.Args) && tok == cmd.Args[j] && !strings.HasPrefix(tok, "-") {
+			args = append(args, tok)
+			j++
+			continue
+		}
+		opt, ok := findOption(options, tok)
+		if !ok || opt.Type == valueNone || cmd.Options[opt.key(tok)] != "true" {
+			continue
+		}
+		// 直後のトークンがパーサーに位置引数として扱われていれば、それをオプションの値にする
+		if i+1 < len(cmd.RawArgs) && j < len(cmd.Args) && cmd.RawArgs[i+1] == cmd.Args[j] {
+			cmd.Options[opt.key(tok)] = cmd.Args[j]
+			j++
+			i++
+		}
+	}
+	cmd.Args = append(args, cmd.Args[j:]...)
+}
+
+// positionalArgs はオプション（値を取るものはその値も）を除いた位置引数を返します
+func positionalArgs(words []string, options []optionSpec) []string {
+	var args []string
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if !strings.HasPrefix(w, "-") || w == "-" {
+			args = append(args, w)
+			continue
+		}
+		if opt, ok := findOption(options, w); ok && opt.Type != valueNone && i+1 < len(words) {
+			i++
+		}
+	}
+	return args
+}
+
+// optionArgs は Options を docker に渡す引数列に戻します（skip に含まれるキーは除外）
+func optionArgs(options map[string]string, skip ...string) []string {
+	skipped := map[string]bool{}
+	for _, k := range skip {
+		skipped[k] = true
+	}
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		if !skipped[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, k := range keys {
+		flag := "--" + k
+		if len(k) == 1 {
+			flag = "-" + k
+		}
+		args = append(args, flag)
+		if v := options[k]; v != "true" {
+			args = append(args, v)
+		}
+	}
+	return args
+}
+
+// completeOptions はオプション名とオプション値を補完します。
+// 入力位置がオプションに関係しない場合は false を返し、通常の引数補完に任せます。
+// project は project <p> logs のようにサービス値の補完に使うプロジェクト名です（不明なら空）。
+func (s *Shell) completeOptions(options []optionSpec, words []string, currentArg, beforeCursor, project string) ([]Suggest, bool) {
+	if len(options) == 0 {
+		return nil, false
+	}
+
+	// --since=10m 形式
+	if strings.HasPrefix(currentArg, "--") && strings.Contains(currentArg, "=") {
+		parts := strings.SplitN(currentArg, "=", 2)
+		opt, ok := findOption(options, parts[0])
+		if !ok || opt.Type == valueNone {
+			return []Suggest{}, true
+		}
+		var suggests []Suggest
+		for _, sg := range s.completeOptionValue(opt, parts[1], project) {
+			suggests = append(suggests, Suggest{Text: parts[0] + "=" + sg.Text, Description: sg.Description})
+		}
+		return suggests, true
+	}
+
+	// 直前のトークンが値を取るオプションなら値を補完
+	prevIdx := len(words) - 1
+	if !strings.HasSuffix(beforeCursor, " ") {
+		prevIdx--
+	}
+	if prevIdx >= 1 {
+		if opt, ok := findOption(options, words[prevIdx]); ok && opt.Type != valueNone {
+			return s.completeOptionValue(opt, currentArg, project), true
+		}
+	}
+
+	if !strings.HasPrefix(currentArg, "-") {
+		return nil, false
+	}
+
+	// 既に指定済みのオプション（入力中の単語は除く）は除外してオプション名を補完
+	done := words[1:]
+	if !strings.HasSuffix(beforeCursor, " ") && len(done) > 0 {
+		done = done[:len(done)-1]
+	}
+	used := map[string]bool{}
+	for _, w := range done {
+		used[strings.SplitN(w, "=", 2)[0]] = true
+	}
+	var suggests []Suggest
+	for _, opt := range options {
+		if used[opt.Name] || used[opt.Short] {
+			continue
+		}
+		desc := opt.description()
+		if ph := opt.placeholder(); ph != "" {
+			desc = ph + "  " + desc
+		}
+		for _, flag := range nonEmpty(opt.Name, opt.Short) {
+			suggests = append(suggests, Suggest{Text: flag, Description: desc})
+		}
+	}
+	return filterHasPrefix(suggests, currentArg, false), true
+}
+
+// completeOptionValue は値の種類に応じた候補を返します
+func (s *Shell) completeOptionValue(opt optionSpec, prefix, project string) []Suggest {
+	switch opt.Type {
+	case valueContainer:
+		return s.completeDockerContainers(prefix, false)
+	case valueImage:
+		return s.completeDockerImages(prefix)
+	case valueService:
+		return s.completeServices(project, prefix)
+	case valueProject:
+		var suggests []Suggest
+		for _, p := range s.detectComposeProjects() {
+			suggests = append(suggests, Suggest{Text: p, Description: i18n.T("completion.value_project")})
+		}
+		return filterFuzzy(suggests, prefix, false)
+	case valueDuration:
+		return completeDurations(prefix)
+	case valueInt:
+		return completeInts(prefix)
+	case valueEnum:
+		var suggests []Suggest
+		for _, v := range opt.Enum {
+			suggests = append(suggests, Suggest{Text: v, Description: opt.description()})
+		}
+		return filterHasPrefix(suggests, prefix, false)
+	default:
+		return []Suggest{}
+	}
+}
+
+// completeServices は compose サービス名を補完します（project が空なら全プロジェクト）
+func (s *Shell) completeServices(project, prefix string) []Suggest {
+	var services []string
+	if project != "" {
+		services = s.detectComposeServices(project)
+	} else {
+		seen := map[string]bool{}
+		for _, p := range s.detectComposeProjects() {
+			for _, sv := range s.detectComposeServices(p) {
+				if !seen[sv] {
+					seen[sv] = true
+					services = append(services, sv)
+				}
+			}
+		}
+	}
+	var suggests []Suggest
+	for _, sv := range services {
+		suggests = append(suggests, Suggest{Text: sv, Description: i18n.T("completion.value_service")})
+	}
+	return withPreviews(filterFuzzy(suggests, prefix, false), s.servicePreviews(project))
+}
+
+// completeDurations は 10m のような期間を補完します。数字だけが入力されていれば単位を補います。
+func completeDurations(prefix string) []Suggest {
+	desc := i18n.T("completion.value_duration")
+	if prefix != "" && strings.Trim(prefix, "0123456789") == "" {
+		return []Suggest{
+			{Text: prefix + "s", Description: desc},
+			{Text: prefix + "m", Description: desc},
+			{Text: prefix + "h", Description: desc},
+		}
+	}
+	var suggests []Suggest
+	for _, d := range []string{"30s", "1m", "5m", "10m", "30m", "1h", "6h", "24h"} {
+		suggests = append(suggests, Suggest{Text: d, Description: desc})
+	}
+	return filterHasPrefix(suggests, prefix, false)
+}
+
+// completeInts は件数などの整数値の候補を返します
+func completeInts(prefix string) []Suggest {
+	desc := i18n.T("completion.value_int")
+	var suggests []Suggest
+	for _, n := range []string{"10", "50", "100", "500", "1000"} {
+		suggests = append(suggests, Suggest{Text: n, Description: desc})
+	}
+	return filterHasPrefix(suggests, prefix, false)
+}
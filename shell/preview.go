@@ -0,0 +1,105 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+
+	"docsh/i18n"
+)
+
+// previewLine はプレビュー欄の「ラベル: 値」の1行を組み立てます
+func previewLine(labelKey, value string) string {
+	if value == "" {
+		value = "-"
+	}
+	return fmt.Sprintf("%s: %s", i18n.T(labelKey), value)
+}
+
+// containerPreviews はコンテナ名ごとのプレビュー（状態・イメージ・ポート・稼働時間）を返します
+func (s *Shell) containerPreviews() map[string][]string {
+	previews := map[string][]string{}
+	for _, line := range s.completion.get(cacheContainerDetails) {
+		f := strings.SplitN(line, "\t", 5)
+		if len(f) < 5 {
+			continue
+		}
+		name, state, status, image, ports := f[0], f[1], f[2], f[3], f[4]
+		uptime := ""
+		if strings.HasPrefix(status, "Up ") {
+			uptime = strings.TrimSpace(strings.TrimPrefix(status, "Up "))
+			status = state
+			if i := strings.Index(uptime, " ("); i >= 0 {
+				// "Up 3 hours (healthy)" の括弧部分は状態として表示する
+				status = state + " " + uptime[i+1:]
+				uptime = uptime[:i]
+			}
+		}
+		previews[name] = []string{
+			previewLine("completion.preview.status", status),
+			previewLine("completion.preview.image", image),
+			previewLine("completion.preview.ports", strings.ReplaceAll(ports, ", ", "\n  ")),
+			previewLine("completion.preview.uptime", uptime),
+		}
+	}
+	return previews
+}
+
+// imagePreviews はイメージ名ごとのプレビュー（サイズ・作成からの経過時間）を返します
+func (s *Shell) imagePreviews() map[string][]string {
+	previews := map[string][]string{}
+	for _, line := range s.completion.get(cacheImageDetails) {
+		f := strings.SplitN(line, "\t", 3)
+		if len(f) < 3 {
+			continue
+		}
+		previews[f[0]] = []string{
+			previewLine("completion.preview.size", f[1]),
+			previewLine("completion.preview.age", f[2]),
+		}
+	}
+	return previews
+}
+
+// servicePreviews は指定プロジェクト（空なら全プロジェクト）のサービスごとのプレビューを返します
+func (s *Shell) servicePreviews(project string) map[string][]string {
+	projects := map[string][]string{}
+	containers := map[string][]string{}
+	for _, l := range s.completion.get(cacheCompose) {
+		parts := strings.SplitN(l, "\t", 3)
+		if len(parts) != 3 || parts[1] == "" || (project != "" && parts[0] != project) {
+			continue
+		}
+		service := parts[1]
+		if !containsString(projects[service], parts[0]) {
+			projects[service] = append(projects[service], parts[0])
+		}
+		if parts[2] != "" {
+			containers[service] = append(containers[service], parts[2])
+		}
+	}
+	previews := map[string][]string{}
+	for service, ps := range projects {
+		previews[service] = []string{
+			previewLine("completion.preview.project", strings.Join(ps, ", ")),
+			previewLine("completion.preview.container", strings.Join(containers[service], ", ")),
+		}
+	}
+	return previews
+}
+
+// withPreviews は候補に対応するプレビューを設定します
+func withPreviews(suggests []Suggest, previews map[string][]string) []Suggest {
+	for i := range suggests {
+		suggests[i].Preview = previews[suggests[i].Text]
+	}
+	return suggests
+}
+
+func containsString(values []string, v string) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
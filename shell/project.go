@@ -5,16 +5,23 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"docsh/i18n"
+	"docsh/internal/compose"
+	"docsh/internal/health"
+	"docsh/themes"
 )
 
 // composeLabels used by Docker Compose
 const (
-	composeProjectLabel    = "com.docker.compose.project"
-	composeWorkingDirLabel = "com.docker.compose.project.working_dir"
-	composeServiceLabel    = "com.docker.compose.service"
+	composeProjectLabel    = compose.ProjectLabel
+	composeWorkingDirLabel = compose.WorkingDirLabel
+	composeServiceLabel    = compose.ServiceLabel
 )
 
 type containerInfo struct {
@@ -25,20 +32,24 @@ type containerInfo struct {
 	Project    string
 	WorkingDir string
 	Service    string
+	DependsOn  string        // raw com.docker.compose.depends_on label
+	State      health.Status // health, restarts, OOM kill and exit code
 }
 
 type projectService struct {
 	ServiceName string
-	Container   containerInfo
+	Container   containerInfo // zero value when the service has no container yet
+	Declared    bool          // declared in the project's compose files
 }
 
 type projectGroup struct {
 	ProjectName string
 	WorkingDir  string
 	Services    []projectService
+	Compose     *composeProject // nil when no compose file was found
 }
 
-// handleProjectCommand implements: project <name> [ps|logs <svc>|restart <svc>|stop]
+// handleProjectCommand implements: project <name> [ps|logs <svc>|start|restart|stop|up|down|build|pull|config|graph]
 func (s *Shell) handleProjectCommand(args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("project name required")
@@ -56,8 +67,8 @@ func (s *Shell) handleProjectCommand(args []string) error {
 		rest = args[2:]
 	}
 
-	// collect containers belonging to projects
-	groups, err := s.collectProjects()
+	// collect projects from container labels and compose files
+	groups, err := s.collectAllProjects()
 	if err != nil {
 		return err
 	}
@@ -71,12 +82,18 @@ func (s *Shell) handleProjectCommand(args []string) error {
 
 	switch action {
 	case "ps":
+		if pg == nil {
+			return fmt.Errorf("project not found: %s", project)
+		}
 		printProjectPS(pg)
 		return nil
 	case "logs":
 		// 2系統の入力を許容する:
-		// 1) 正規: project <project> logs <service> [options]
+		// 1) 正規: project <project> logs [service...] [options]（全サービスを色付きで多重化）
 		// 2) 省略: project <service> logs [options]
+		if pg != nil {
+			return s.projectLogs(pg, rest)
+		}
 		if len(rest) == 0 || strings.HasPrefix(rest[0], "-") {
 			// 省略系: 第一引数はサービス名、rest はオプション
 			service := project
@@ -89,6 +106,9 @@ func (s *Shell) handleProjectCommand(args []string) error {
 			for i := range groups {
 				g := &groups[i]
 				for _, svc := range g.Services {
+					if svc.Container.Names == "" {
+						continue
+					}
 					if svc.ServiceName == service || svc.Container.Names == service {
 						foundProjects = append(foundProjects, g.ProjectName)
 						foundContainer = svc.Container.Names
@@ -103,69 +123,103 @@ func (s *Shell) handleProjectCommand(args []string) error {
 			}
 			return s.execDocker("logs", append(options, foundContainer)...)
 		}
-		// 正規系: pg が必要
-		if pg == nil {
-			return fmt.Errorf("project not found: %s", project)
-		}
-		// docker logs は [OPTIONS] CONTAINER 順
-		container := serviceContainerName(pg, rest[0])
-		options := rest[1:]
-		return s.execDocker("logs", append(options, container)...)
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	if pg == nil {
+		return fmt.Errorf("project not found: %s", project)
+	}
+	switch action {
 	case "start":
-		composeFile := filepath.Join(pg.WorkingDir, "docker-compose.yml")
 		if len(rest) == 0 {
-			// start whole project (prefer docker compose)
-			if fileExists(composeFile) {
-				return s.execDocker("compose", "-f", composeFile, "up", "-d")
-			}
-			for _, svc := range pg.Services {
-				_ = s.execDocker("start", svc.Container.Names)
+			// services that were never created need docker compose; it honors depends_on itself
+			if pg.Compose != nil && hasUncreatedService(pg) {
+				return s.execCompose(pg.Compose, "up", "-d")
 			}
-			return nil
+			return s.startProjectInOrder(pg)
 		}
 		// single service
-		if fileExists(composeFile) {
-			return s.execDocker("compose", "-f", composeFile, "start", rest[0])
+		if pg.Compose != nil {
+			return s.execCompose(pg.Compose, "start", rest[0])
 		}
 		return s.execDocker("start", serviceContainerName(pg, rest[0]))
-	case "restart":
-		composeFile := filepath.Join(pg.WorkingDir, "docker-compose.yml")
+	case "restart", "stop":
 		if len(rest) == 0 {
-			if fileExists(composeFile) {
-				return s.execDocker("compose", "-f", composeFile, "restart")
+			// stop dependents first, then start dependencies first
+			if err := s.stopProjectInOrder(pg); err != nil || action == "stop" {
+				return err
 			}
-			for _, svc := range pg.Services {
-				_ = s.execDocker("restart", svc.Container.Names)
-			}
-			return nil
+			return s.startProjectInOrder(pg)
 		}
-		if fileExists(composeFile) {
-			return s.execDocker("compose", "-f", composeFile, "restart", rest[0])
+		if pg.Compose != nil {
+			return s.execCompose(pg.Compose, action, rest...)
 		}
-		return s.execDocker("restart", serviceContainerName(pg, rest[0]))
-	case "stop":
-		composeFile := filepath.Join(pg.WorkingDir, "docker-compose.yml")
-		if len(rest) == 0 {
-			if fileExists(composeFile) {
-				return s.execDocker("compose", "-f", composeFile, "stop")
-			}
-			for _, svc := range pg.Services {
-				_ = s.execDocker("stop", svc.Container.Names)
-			}
-			return nil
+		return s.execDocker(action, serviceContainerName(pg, rest[0]))
+	case "graph":
+		if containsString(rest, "--dot") {
+			printDependencyDOT(pg)
+		} else {
+			printDependencyTree(pg)
+		}
+		return nil
+	case "up", "down", "build", "pull", "config":
+		if pg.Compose == nil {
+			return fmt.Errorf(i18n.T("compose.no_compose_file"), pg.ProjectName)
 		}
-		if fileExists(composeFile) {
-			return s.execDocker("compose", "-f", composeFile, "stop", rest[0])
+		// up runs detached unless asked otherwise, so the shell stays usable
+		if action == "up" && !containsString(rest, "-d") && !containsString(rest, "--detach") && !containsString(rest, "--attach") {
+			rest = append([]string{"-d"}, rest...)
 		}
-		return s.execDocker("stop", serviceContainerName(pg, rest[0]))
+		return s.execCompose(pg.Compose, action, rest...)
 	default:
 		return fmt.Errorf("unknown project action: %s", action)
 	}
 }
 
+// projectLogs streams the logs of the given services (all services when none are named),
+// each line prefixed with the service name in its theme color and merged by timestamp
+func (s *Shell) projectLogs(pg *projectGroup, args []string) error {
+	opts, names, err := parseMuxLogOptions(args, muxLogsOptions)
+	if err != nil {
+		return err
+	}
+	var sources []logSource
+	for _, name := range names {
+		if strings.HasPrefix(name, "-") {
+			return fmt.Errorf("unknown option: %s", name)
+		}
+		i := serviceIndex(pg, name)
+		if i < 0 || pg.Services[i].Container.Names == "" {
+			return fmt.Errorf("service not found: %s", name)
+		}
+		sources = append(sources, logSource{Label: name, Container: pg.Services[i].Container.Names, Color: i})
+	}
+	if len(names) == 0 {
+		for i, svc := range pg.Services {
+			if svc.Container.Names != "" {
+				sources = append(sources, logSource{Label: svc.ServiceName, Container: svc.Container.Names, Color: i})
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf(i18n.T("multilog.no_containers"), pg.ProjectName)
+	}
+	return s.runMultiplexedLogs(sources, opts)
+}
+
+// hasUncreatedService reports whether a declared service has no container yet
+func hasUncreatedService(pg *projectGroup) bool {
+	for _, svc := range pg.Services {
+		if svc.Container.Names == "" {
+			return true
+		}
+	}
+	return false
+}
+
 func serviceContainerName(pg *projectGroup, service string) string {
 	for _, svc := range pg.Services {
-		if svc.ServiceName == service {
+		if svc.ServiceName == service && svc.Container.Names != "" {
 			return svc.Container.Names
 		}
 	}
@@ -175,22 +229,48 @@ func serviceContainerName(pg *projectGroup, service string) string {
 func printProjectPS(pg *projectGroup) {
 	fmt.Printf("Project: %s (%s)\n", pg.ProjectName, pg.WorkingDir)
 	fmt.Printf("Services:\n")
-	for _, svc := range pg.Services {
-		status := strings.TrimSpace(svc.Container.Status)
+	printProjectServices(pg)
+	// Ensure prompt does not overwrite the last service line
+	fmt.Println()
+}
+
+// printProjectServices prints one line per service, marking services that exist only
+// in the compose files or only as containers. The status includes health, restarts and
+// exit reasons and is colored like ps.
+func printProjectServices(pg *projectGroup) {
+	statuses := make([]string, len(pg.Services))
+	width := 8
+	for i, svc := range pg.Services {
+		switch {
+		case svc.Container.ID == "":
+			statuses[i] = i18n.T("compose.not_created")
+		case svc.Container.State.State != "":
+			statuses[i] = svc.Container.State.Summary()
+		default:
+			statuses[i] = strings.TrimSpace(svc.Container.Status)
+		}
+		if svc.Container.ID != "" && pg.Compose != nil && !svc.Declared {
+			statuses[i] += " " + i18n.T("compose.not_declared")
+		}
+		width = max(width, lipgloss.Width(statuses[i]))
+	}
+	for i, svc := range pg.Services {
+		status := statuses[i]
+		if svc.Container.ID != "" {
+			status = themes.Colorize(status, svc.Container.State.Color())
+		}
 		ports := strings.TrimSpace(svc.Container.Ports)
 		if ports != "" && ports != "-" {
-			fmt.Printf("  %-10s %-8s %s\n", svc.ServiceName, status, ports)
+			fmt.Printf("  %-10s %s %s\n", svc.ServiceName, status+strings.Repeat(" ", width-lipgloss.Width(statuses[i])), ports)
 		} else {
 			fmt.Printf("  %-10s %s\n", svc.ServiceName, status)
 		}
 	}
-	// Ensure prompt does not overwrite the last service line
-	fmt.Println()
 }
 
 // psByProject implements: ps --by-project
 func (s *Shell) psByProject() error {
-	groups, err := s.collectProjects()
+	groups, err := s.collectAllProjects()
 	if err != nil {
 		return err
 	}
@@ -211,15 +291,7 @@ func (s *Shell) psByProject() error {
 	for i := range groups {
 		pg := &groups[i]
 		fmt.Printf("\n📦 %s (%s)\n", pg.ProjectName, pg.WorkingDir)
-		for _, svc := range pg.Services {
-			status := strings.TrimSpace(svc.Container.Status)
-			ports := strings.TrimSpace(svc.Container.Ports)
-			if ports != "" && ports != "-" {
-				fmt.Printf("  %-10s %-8s %s\n", svc.ServiceName, status, ports)
-			} else {
-				fmt.Printf("  %-10s %s\n", svc.ServiceName, status)
-			}
-		}
+		printProjectServices(pg)
 	}
 	// 末尾に空行を出して、REPL のプロンプトが直前行を上書きしないようにする
 	fmt.Println()
@@ -243,7 +315,8 @@ func (s *Shell) collectProjects() ([]projectGroup, error) {
 		// Ports as JSON to reconstruct mapping
 		// Use custom delimiter to avoid accidental splitting by tabs inside values
 		delim := "::DOCSH::"
-		format := "{{.Id}}" + delim + "{{.Name}}" + delim + "{{.State.Status}}" + delim + "{{json .NetworkSettings.Ports}}" + delim + "{{index .Config.Labels \"com.docker.compose.project\"}}" + delim + "{{index .Config.Labels \"com.docker.compose.project.working_dir\"}}" + delim + "{{index .Config.Labels \"com.docker.compose.service\"}}"
+		format := "{{.Id}}" + delim + "{{.Name}}" + delim + "{{.State.Status}}" + delim + "{{json .NetworkSettings.Ports}}" + delim + "{{index .Config.Labels \"com.docker.compose.project\"}}" + delim + "{{index .Config.Labels \"com.docker.compose.project.working_dir\"}}" + delim + "{{index .Config.Labels \"com.docker.compose.service\"}}" + delim + "{{index .Config.Labels \"com.docker.compose.depends_on\"}}" +
+			delim + "{{if .State.Health}}{{.State.Health.Status}}{{end}}" + delim + "{{.RestartCount}}" + delim + "{{.State.OOMKilled}}" + delim + "{{.State.ExitCode}}" + delim + "{{.State.Error}}"
 		out, err := exec.Command("docker", "inspect", "-f", format, id).Output()
 		if err != nil {
 			continue
@@ -268,30 +341,21 @@ func (s *Shell) collectProjects() ([]projectGroup, error) {
 		if len(fields) >= 7 {
 			ci.Service = normalizeVal(fields[6])
 		}
-		// 補助: service が空で、Names が "<project>-<service>-N" または "<service>" 形式なら補完
-		if ci.Service == "" && ci.Project != "" {
-			n := ci.Names
-			base := n
-			if strings.HasPrefix(n, ci.Project+"-") {
-				// hyphen 区切り: project-service-index
-				parts := strings.Split(n, "-")
-				if len(parts) >= 2 {
-					base = parts[1]
-				}
-			} else if strings.HasPrefix(n, ci.Project+"_") {
-				// underscore 区切り: project_service_index
-				parts := strings.Split(n, "_")
-				if len(parts) >= 2 {
-					base = parts[1]
-				}
-			}
-			// index サフィックス除去
-			base = strings.TrimSuffix(base, "-1")
-			base = strings.TrimSuffix(base, "_1")
-			if base != "" && base != ci.Project {
-				ci.Service = base
+		if len(fields) >= 8 {
+			ci.DependsOn = normalizeVal(fields[7])
+		}
+		ci.State = health.Status{State: ci.Status}
+		if len(fields) >= 13 {
+			if ci.Status == "running" {
+				ci.State.Health = normalizeVal(fields[8])
 			}
+			ci.State.RestartCount, _ = strconv.Atoi(fields[9])
+			ci.State.OOMKilled = fields[10] == "true"
+			ci.State.ExitCode, _ = strconv.Atoi(fields[11])
+			ci.State.Error = strings.TrimSpace(fields[12])
 		}
+		// 補助: service が空で、Names が "<project>-<service>-N" または "<service>" 形式なら補完
+		ci.Service = compose.ServiceName(ci.Names, ci.Project, ci.Service)
 
 		if os.Getenv("DOCSH_DEBUG") == "1" {
 			fmt.Printf("[DEBUG] id=%s name=%s project=%s service=%s workdir=%s\n", ci.ID[:12], ci.Names, ci.Project, ci.Service, ci.WorkingDir)
@@ -32,6 +32,15 @@ func (s *Shell) buildPrompt() string {
 		prompt = "🐳 %s $ "
 	}
 
+	// show_in_prompt が有効で %c を含まないプロンプトには、パスの後ろに現在のコンテナとその状態を表示
+	if s.config != nil && s.config.ContextShowInPrompt && s.currentContainer() != "" && !strings.Contains(prompt, "%c") {
+		if strings.Contains(prompt, "%s") {
+			prompt = strings.Replace(prompt, "%s", "%s [%c] %H", 1)
+		} else {
+			prompt = "[%c] %H " + prompt
+		}
+	}
+
 	// 変数展開とクリーンアップ
 	result := s.expandPromptVariables(prompt)
 	return s.cleanPrompt(result)
@@ -65,7 +74,9 @@ func (s *Shell) expandPromptVariables(prompt string) string {
 	// 基本的な変数展開
 	variables := map[string]string{
 		"%s":   s.getShortPath(),
-		"%d":   s.getCurrentDir(),
+		"%d":   s.currentLocation(),
+		"%c":   s.currentContainer(),
+		"%H":   s.getContainerHealth(),
 		"%u":   s.getUsername(),
 		"%h":   s.getHostname(),
 		"%t":   s.getTime(),
@@ -89,6 +100,10 @@ func (s *Shell) expandPromptVariables(prompt string) string {
 }
 
 func (s *Shell) getShortPath() string {
+	// コンテナ内ブラウズ中は web:/var/log のように表示
+	if s.containerCwd != nil {
+		return s.containerCwd.String()
+	}
 	currentDir := s.getCurrentDir()
 	homeDir, err := os.UserHomeDir()
 	if err == nil && strings.HasPrefix(currentDir, homeDir) {
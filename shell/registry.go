@@ -0,0 +1,193 @@
+package shell
+
+import (
+	"strings"
+
+	"docsh/i18n"
+	"docsh/internal/engine"
+	"docsh/internal/parser"
+)
+
+// commandKind はレジストリに登録されたコマンドの種別です
+type commandKind int
+
+const (
+	kindBuiltin   commandKind = iota // シェル内蔵コマンド（help, alias, theme など）
+	kindLifecycle                    // Docker ライフサイクルコマンド（start, stop, rm など）
+	kindMapping                      // mappings.yaml 由来の Linux -> Docker マッピング
+	kindAlias                        // ユーザー定義エイリアス
+)
+
+// safetyLevel はコマンドが Docker の状態に与える影響の大きさです
+type safetyLevel int
+
+const (
+	safetyReadOnly    safetyLevel = iota // 参照のみ
+	safetyMutating                       // 状態を変更する（start, stop など）
+	safetyDestructive                    // 取り消せない変更（rm, rmi など）
+)
+
+// outputMode はコマンド出力の扱い方です
+type outputMode int
+
+const (
+	outputDirect      outputMode = iota // ハンドラが標準出力へ直接書き込む
+	outputCaptured                      // executor の実行結果を受け取って表示する
+	outputStreaming                     // 終了するまで出力を流し続ける（tail -f, top など）
+	outputInteractive                   // 端末を占有する（login, htop など）
+)
+
+// argKind は位置引数の補完に使う値の種類です
+type argKind int
+
+const (
+	argNone argKind = iota
+	argContainer
+	argRunningContainer
+	argImage
+	argFile
+	argDirectory
+	argPath
+	argTheme
+	argLanguage
+	argCommand
+	argContainerPath // ホストのパスまたは container:/path
+)
+
+// optionSpec はコマンドが受け付けるオプションの定義です
+type optionSpec struct {
+	Name        string    // 長い形式（--force）。無い場合は空
+	Short       string    // 短い形式（-f）。無い場合は空
+	Type        valueKind // 値の種類（valueNone はフラグ）
+	Enum        []string  // valueEnum の候補
+	DescKey     string    // i18n キー（空の場合は Description を使用）
+	Description string
+}
+
+// commandHandler はパース済みコマンドを実行するハンドラです
+type commandHandler func(s *Shell, cmd *parser.ParsedCommand) error
+
+// completerFunc は引数位置の補完候補を返す関数です
+type completerFunc func(s *Shell, words []string, currentArg, line string) []Suggest
+
+// commandSpec はレジストリに登録される1コマンド分のハンドラとメタデータです
+type commandSpec struct {
+	Name        string
+	Kind        commandKind
+	Usage       string
+	DescKey     string // i18n キー（空の場合は Description を使用）
+	Description string
+	Safety      safetyLevel
+	Output      outputMode
+	Args        argKind
+	Options     []optionSpec
+	Examples    []string
+	Complete    completerFunc
+	Mappings    []*engine.CommandMapping // このコマンド名に対応するマッピング（help 表示用）
+	AliasOf     string                   // kindAlias の展開先
+	Hidden      bool                     // コマンド名補完に出さない
+	Context     bool                     // コンテナ省略時に use で設定した現在のコンテナを対象にする
+	Handler     commandHandler
+}
+
+// description は現在の言語での説明文を返します
+func (c *commandSpec) description() string {
+	if c.Kind == kindAlias {
+		return i18n.T("completion.alias_value", c.AliasOf)
+	}
+	if c.DescKey != "" {
+		return i18n.T(c.DescKey)
+	}
+	return c.Description
+}
+
+// commandRegistry は内蔵コマンド・ライフサイクル・マッピング・エイリアスを一元管理します
+type commandRegistry struct {
+	specs   map[string]*commandSpec
+	order   []string
+	aliases map[string]*commandSpec
+}
+
+func newCommandRegistry() *commandRegistry {
+	return &commandRegistry{
+		specs:   make(map[string]*commandSpec),
+		aliases: make(map[string]*commandSpec),
+	}
+}
+
+// register はコマンドを登録します（同名の登録は後勝ち、並び順は最初の登録を維持）
+func (r *commandRegistry) register(spec *commandSpec) {
+	if _, exists := r.specs[spec.Name]; !exists {
+		r.order = append(r.order, spec.Name)
+	}
+	r.specs[spec.Name] = spec
+}
+
+// lookup はエイリアスを含めずにコマンドを検索します
+func (r *commandRegistry) lookup(name string) (*commandSpec, bool) {
+	spec, ok := r.specs[name]
+	return spec, ok
+}
+
+// resolve はエイリアスを優先してコマンドを検索します
+func (r *commandRegistry) resolve(name string) (*commandSpec, bool) {
+	if spec, ok := r.aliases[name]; ok {
+		return spec, true
+	}
+	return r.lookup(name)
+}
+
+// all は登録順に全コマンドを返します
+func (r *commandRegistry) all() []*commandSpec {
+	specs := make([]*commandSpec, 0, len(r.order))
+	for _, name := range r.order {
+		specs = append(specs, r.specs[name])
+	}
+	return specs
+}
+
+// attachMappings はマッピングをベースコマンド名ごとにレジストリへ紐付けます。
+// 既に内蔵/ライフサイクルとして登録済みの名前はハンドラを維持し、メタデータだけを追加します。
+func (r *commandRegistry) attachMappings(mappings []*engine.CommandMapping) {
+	for _, m := range mappings {
+		fields := strings.Fields(m.LinuxCommand)
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		if spec, ok := r.specs[name]; ok {
+			spec.Mappings = append(spec.Mappings, m)
+			continue
+		}
+		r.register(&commandSpec{
+			Name:        name,
+			Kind:        kindMapping,
+			Usage:       m.LinuxExample,
+			Description: m.Description,
+			Output:      outputCaptured,
+			Mappings:    []*engine.CommandMapping{m},
+			Handler:     (*Shell).runMappedCommand,
+		})
+	}
+}
+
+// syncAliases は設定のエイリアス定義でエイリアス登録を置き換えます
+func (r *commandRegistry) syncAliases(aliases map[string]string) {
+	r.aliases = make(map[string]*commandSpec, len(aliases))
+	for name, command := range aliases {
+		r.aliases[name] = &commandSpec{
+			Name:    name,
+			Kind:    kindAlias,
+			AliasOf: command,
+		}
+	}
+}
+
+// aliasSpecs は登録済みのエイリアスを返します
+func (r *commandRegistry) aliasSpecs() []*commandSpec {
+	specs := make([]*commandSpec, 0, len(r.aliases))
+	for _, spec := range r.aliases {
+		specs = append(specs, spec)
+	}
+	return specs
+}
@@ -2,6 +2,7 @@ package shell
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -46,6 +47,9 @@ func (m replModel) Init() tea.Cmd {
 
 type execDoneMsg struct{ err error }
 
+// completionUpdatedMsg は補完キャッシュの更新通知です
+type completionUpdatedMsg struct{}
+
 func runCommandCmd(s *Shell, line string) tea.Cmd {
 	line = strings.TrimSpace(line)
 	if line == "" {
@@ -57,6 +61,22 @@ func runCommandCmd(s *Shell, line string) tea.Cmd {
 	}
 }
 
+// shellExecCommand はシェルのコマンド実行を tea.ExecCommand として扱うためのアダプタです
+type shellExecCommand struct {
+	run func() error
+}
+
+func (c shellExecCommand) Run() error          { return c.run() }
+func (c shellExecCommand) SetStdin(io.Reader)  {}
+func (c shellExecCommand) SetStdout(io.Writer) {}
+func (c shellExecCommand) SetStderr(io.Writer) {}
+
+// runInteractiveCmd は REPL の描画と入力を停止した状態でコマンドを実行します
+func runInteractiveCmd(s *Shell, line string) tea.Cmd {
+	run := shellExecCommand{run: func() error { return s.executeCommand(line) }}
+	return tea.Exec(run, func(err error) tea.Msg { return execDoneMsg{err: err} })
+}
+
 func (m replModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -70,7 +90,7 @@ func (m replModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			line := m.input.Value()
 			trimmed := strings.TrimSpace(line)
-			if trimmed == "exit" {
+			if trimmed == "exit" || trimmed == "quit" {
 				// exit はREPL側で終了
 				return m, tea.Quit
 			}
@@ -92,24 +112,9 @@ func (m replModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.historyIndex = -1
 			m.input.SetValue("")
 			m.suggestions = nil
-			// login のような外部対話は REPL終了前に pendingExternal をセット
-			parts := strings.Fields(trimmed)
-			if len(parts) > 0 && parts[0] == "login" {
-				if len(parts) < 2 {
-					fmt.Println("container name required")
-					m.isExecuting = false
-					m.echoLine = ""
-					return m, nil
-				}
-				if err := m.shell.enterContainer(parts[1]); err != nil {
-					fmt.Printf("%v\n", err)
-				}
-				// コンテナから戻ったらREPLを継続
-				m.isExecuting = false
-				m.echoLine = ""
-				m.input.Focus()
-				m.input.SetCursorMode(textinput.CursorHide)
-				return m, tea.HideCursor
+			// login / htop のように端末を占有するコマンドは tea.Exec で端末を明け渡して実行
+			if m.shell.isInteractiveCommand(trimmed) {
+				return m, runInteractiveCmd(m.shell, trimmed)
 			}
 			return m, runCommandCmd(m.shell, line)
 		case "tab":
@@ -120,16 +125,21 @@ func (m replModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				line := m.input.Value()
 				hasTrailingSpace := strings.HasSuffix(line, " ")
 				tokens := strings.Fields(line)
+				// ディレクトリや "container:" は続けて入力するので区切りのスペースを付けない
+				sep := " "
+				if strings.HasSuffix(chosen, "/") || strings.HasSuffix(chosen, ":") {
+					sep = ""
+				}
 				if len(tokens) == 0 {
-					m.input.SetValue(chosen + " ")
+					m.input.SetValue(chosen + sep)
 				} else if hasTrailingSpace {
 					// 末尾スペースあり: 既存トークンは保持し、新しいトークンを追加
 					tokens = append(tokens, chosen)
-					m.input.SetValue(strings.Join(tokens, " ") + " ")
+					m.input.SetValue(strings.Join(tokens, " ") + sep)
 				} else {
 					// 末尾スペースなし: 最後のトークンを確定置換
 					tokens[len(tokens)-1] = chosen
-					m.input.SetValue(strings.Join(tokens, " ") + " ")
+					m.input.SetValue(strings.Join(tokens, " ") + sep)
 				}
 				m.input.CursorEnd()
 				m.suggestions = m.shell.Complete(m.input.Value())
@@ -182,6 +192,12 @@ func (m replModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 		}
+	case completionUpdatedMsg:
+		if !m.isExecuting && strings.TrimSpace(m.input.Value()) != "" {
+			m.suggestions = m.shell.Complete(m.input.Value())
+			m.selectedIdx = clampIndex(m.selectedIdx, len(m.suggestions))
+		}
+		return m, nil
 	case execDoneMsg:
 		// 実行完了後にプロンプトを復帰
 		// エラーがあれば表示（従来はREPL側で非表示だったため、何も出ない問題があった）
@@ -215,8 +231,19 @@ var (
 	suggestionStyle = lipgloss.NewStyle()
 	suggestionSel   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
 	suggestionDesc  = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	suggestionMatch = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	previewStyle    = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("241")).
+			Padding(0, 1)
 )
 
+// maxVisibleSuggestions は補完メニューに一度に表示する候補数です
+const maxVisibleSuggestions = 10
+
+// minPreviewWidth はプレビュー欄を表示する最小の端末幅です
+const minPreviewWidth = 60
+
 func (m replModel) View() string {
 	var b strings.Builder
 	if !m.isExecuting {
@@ -233,33 +260,77 @@ func (m replModel) View() string {
 		}
 	}
 
-	// サジェスト（最大10件）
-	if !m.isExecuting {
-		max := minInt(10, len(m.suggestions))
-		for i := 0; i < max; i++ {
-			s := m.suggestions[i]
-			var line string
-			if i == m.selectedIdx {
-				line = suggestionSel.Render(fmt.Sprintf("%s", s.Text))
-			} else {
-				line = suggestionStyle.Render(s.Text)
-			}
-			if s.Description != "" {
-				line += "  " + suggestionDesc.Render(s.Description)
-			}
-			b.WriteString(line)
-			b.WriteString("\n")
+	if !m.isExecuting && len(m.suggestions) > 0 {
+		menu := m.renderSuggestionMenu()
+		sel := m.suggestions[clampIndex(m.selectedIdx, len(m.suggestions))]
+		if len(sel.Preview) > 0 && (m.width == 0 || m.width >= minPreviewWidth) {
+			preview := previewStyle.Render(strings.Join(sel.Preview, "\n"))
+			menu = lipgloss.JoinHorizontal(lipgloss.Top, menu, "  ", preview)
 		}
+		b.WriteString(menu)
+		b.WriteString("\n")
 	}
 
 	return b.String()
 }
 
+// renderSuggestionMenu は選択中の候補が見える範囲（最大 maxVisibleSuggestions 件）を描画します
+func (m replModel) renderSuggestionMenu() string {
+	sel := clampIndex(m.selectedIdx, len(m.suggestions))
+	start := 0
+	if sel >= maxVisibleSuggestions {
+		start = sel - maxVisibleSuggestions + 1
+	}
+	end := minInt(start+maxVisibleSuggestions, len(m.suggestions))
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		s := m.suggestions[i]
+		base := suggestionStyle
+		if i == sel {
+			base = suggestionSel
+		}
+		line := renderMatches(s.Text, s.Matches, base)
+		if s.Description != "" {
+			line += "  " + suggestionDesc.Render(s.Description)
+		}
+		lines = append(lines, line)
+	}
+	if len(m.suggestions) > maxVisibleSuggestions {
+		lines = append(lines, suggestionDesc.Render(fmt.Sprintf("(%d/%d)", sel+1, len(m.suggestions))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderMatches は fuzzy マッチで一致した文字を強調して描画します
+func renderMatches(text string, matches []int, base lipgloss.Style) string {
+	if len(matches) == 0 {
+		return base.Render(text)
+	}
+	matched := make(map[int]bool, len(matches))
+	for _, i := range matches {
+		matched[i] = true
+	}
+	highlight := base.Copy().Inherit(suggestionMatch)
+	var b strings.Builder
+	for i, r := range []rune(text) {
+		if matched[i] {
+			b.WriteString(highlight.Render(string(r)))
+		} else {
+			b.WriteString(base.Render(string(r)))
+		}
+	}
+	return b.String()
+}
+
 // StartBubbleTeaREPL は Bubble Tea ベースの REPL を起動します
 func (s *Shell) StartBubbleTeaREPL() error {
 	p := tea.NewProgram(newReplModel(s))
 	s.teaProgram = p
+	// 補完データがバックグラウンドで更新されたら候補を再計算させる
+	s.completion.setOnUpdate(func() { p.Send(completionUpdatedMsg{}) })
 	_, err := p.Run()
+	s.completion.setOnUpdate(nil)
 	return err
 }
 
@@ -0,0 +1,334 @@
+package shell
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"docsh/i18n"
+)
+
+// selectorKind は対象選択子の種類です
+type selectorKind int
+
+const (
+	selectName    selectorKind = iota // 完全一致の名前（または ID の前方一致）
+	selectGlob                        // web-* のような名前のワイルドカード
+	selectRegex                       // /web-\d+/ のような名前の正規表現
+	selectLabel                       // label=key=value / label=key
+	selectStatus                      // status=exited
+	selectImage                       // image=nginx*
+	selectProject                     // project=foo（compose プロジェクト）
+)
+
+// targetSelector はコマンド引数1つ分の対象選択子です
+type targetSelector struct {
+	Kind  selectorKind
+	Value string
+	Raw   string // 入力されたままの選択子（メッセージ用）
+	re    *regexp.Regexp
+}
+
+// dockerTarget は選択子から解決されたコンテナまたはイメージです
+type dockerTarget struct {
+	ID    string
+	Name  string
+	Image string
+	State string
+}
+
+// selectorKeys は key=value 形式で受け付ける選択子のキーです
+var selectorKeys = map[string]selectorKind{
+	"label":   selectLabel,
+	"status":  selectStatus,
+	"image":   selectImage,
+	"project": selectProject,
+	"name":    selectGlob,
+}
+
+// isSelectorArg は引数が単純な名前ではなく選択子かどうかを返します
+func isSelectorArg(arg string) bool {
+	if strings.ContainsAny(arg, "*?[") || (len(arg) > 2 && strings.HasPrefix(arg, "/") && strings.HasSuffix(arg, "/")) {
+		return true
+	}
+	if i := strings.Index(arg, "="); i > 0 {
+		_, ok := selectorKeys[arg[:i]]
+		return ok
+	}
+	return false
+}
+
+// labelOption は選択子を受け付けるコマンド共通の --label オプションです
+var labelOption = optionSpec{Name: "--label", Type: valueString, DescKey: "help.option_descriptions.label_selector"}
+
+// hasLabelOption は --label による選択が指定されているかを返します
+func hasLabelOption(raw []string) bool {
+	for _, a := range raw {
+		if a == "--label" || strings.HasPrefix(a, "--label=") {
+			return true
+		}
+	}
+	return false
+}
+
+// wantsTargets は引数に選択子や --label が含まれるか、名前が複数あるかを返します（単一対象の従来処理と区別する）
+func wantsTargets(raw []string, options []optionSpec) bool {
+	if hasLabelOption(raw) {
+		return true
+	}
+	args := positionalArgs(raw, options)
+	if len(args) > 1 {
+		return true
+	}
+	for _, a := range args {
+		if isSelectorArg(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTargetSelectors は位置引数と --label の値を選択子に変換します。
+// options はコマンドのオプション定義で、値を取るオプションの値を選択子と取り違えないために使います。
+func parseTargetSelectors(raw []string, options []optionSpec) ([]targetSelector, error) {
+	var selectors []targetSelector
+	for i := 0; i < len(raw); i++ {
+		arg := raw[i]
+		switch {
+		case arg == "--label":
+			if i+1 >= len(raw) {
+				return nil, fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			i++
+			selectors = append(selectors, targetSelector{Kind: selectLabel, Value: raw[i], Raw: "label=" + raw[i]})
+			continue
+		case strings.HasPrefix(arg, "--label="):
+			selectors = append(selectors, targetSelector{Kind: selectLabel, Value: strings.TrimPrefix(arg, "--label="), Raw: arg})
+			continue
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			if opt, ok := findOption(options, arg); ok && opt.Type != valueNone && !strings.Contains(arg, "=") {
+				i++
+			}
+			continue
+		}
+		sel, err := parseTargetSelector(arg)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+	}
+	return selectors, nil
+}
+
+func parseTargetSelector(arg string) (targetSelector, error) {
+	if len(arg) > 2 && strings.HasPrefix(arg, "/") && strings.HasSuffix(arg, "/") {
+		re, err := regexp.Compile(arg[1 : len(arg)-1])
+		if err != nil {
+			return targetSelector{}, fmt.Errorf(i18n.T("selector.invalid_regex"), arg, err)
+		}
+		return targetSelector{Kind: selectRegex, Value: arg, Raw: arg, re: re}, nil
+	}
+	if i := strings.Index(arg, "="); i > 0 {
+		if kind, ok := selectorKeys[arg[:i]]; ok {
+			value := arg[i+1:]
+			if value == "" {
+				return targetSelector{}, fmt.Errorf(i18n.T("selector.missing_value"), arg)
+			}
+			sel := targetSelector{Kind: kind, Value: value, Raw: arg}
+			if kind == selectGlob || kind == selectImage {
+				sel.re = globRegexp(value)
+			}
+			return sel, nil
+		}
+	}
+	if strings.ContainsAny(arg, "*?[") {
+		return targetSelector{Kind: selectGlob, Value: arg, Raw: arg, re: globRegexp(arg)}, nil
+	}
+	return targetSelector{Kind: selectName, Value: arg, Raw: arg}, nil
+}
+
+// globRegexp はワイルドカードを正規表現に変換します。イメージ名の "/" も * に含めます。
+func globRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			if j := strings.IndexByte(glob[i:], ']'); j > 0 {
+				class := glob[i+1 : i+j]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += j
+				continue
+			}
+			b.WriteString(regexp.QuoteMeta("["))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return regexp.MustCompile("^" + regexp.QuoteMeta(glob) + "$")
+	}
+	return re
+}
+
+// matchesName は名前の選択子（名前・ワイルドカード・正規表現）に一致するかを返します
+func (sel targetSelector) matchesName(t dockerTarget) bool {
+	switch sel.Kind {
+	case selectName:
+		return t.Name == sel.Value || (len(sel.Value) >= 3 && strings.HasPrefix(t.ID, sel.Value))
+	case selectGlob:
+		return sel.re.MatchString(t.Name)
+	case selectRegex:
+		return sel.re.MatchString(t.Name)
+	}
+	return false
+}
+
+// matchesImage はイメージ名（タグ省略も可）がワイルドカードに一致するかを返します
+func (sel targetSelector) matchesImage(image string) bool {
+	if sel.re.MatchString(image) {
+		return true
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return sel.re.MatchString(image[:i])
+	}
+	return false
+}
+
+// splitSelectors は名前の選択子（和集合）と絞り込みの選択子（積集合）に分けます
+func splitSelectors(selectors []targetSelector) (names, filters []targetSelector) {
+	for _, sel := range selectors {
+		switch sel.Kind {
+		case selectName, selectGlob, selectRegex:
+			names = append(names, sel)
+		default:
+			filters = append(filters, sel)
+		}
+	}
+	return names, filters
+}
+
+func matchesAnyName(names []targetSelector, t dockerTarget) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, sel := range names {
+		if sel.matchesName(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveContainerTargets は選択子に一致するコンテナを列挙します。
+// label / status / project は docker の --filter に任せ、名前とイメージはここで照合します。
+func (s *Shell) resolveContainerTargets(selectors []targetSelector) ([]dockerTarget, error) {
+	if !s.shellExecutor.IsDockerAvailable() {
+		return nil, fmt.Errorf(i18n.T("docker.not_available"))
+	}
+	names, filters := splitSelectors(selectors)
+	args := []string{"ps", "-a", "--format", "{{.ID}}\t{{.Names}}\t{{.Image}}\t{{.State}}"}
+	var images []targetSelector
+	for _, sel := range filters {
+		switch sel.Kind {
+		case selectLabel:
+			args = append(args, "--filter", "label="+sel.Value)
+		case selectStatus:
+			args = append(args, "--filter", "status="+sel.Value)
+		case selectProject:
+			args = append(args, "--filter", "label="+composeProjectLabel+"="+sel.Value)
+		case selectImage:
+			images = append(images, sel)
+		}
+	}
+	output, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("selector.resolve_failed"), err)
+	}
+
+	var targets []dockerTarget
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 4 {
+			continue
+		}
+		t := dockerTarget{ID: parts[0], Name: parts[1], Image: parts[2], State: parts[3]}
+		if !matchesAnyName(names, t) {
+			continue
+		}
+		matched := true
+		for _, sel := range images {
+			if !sel.matchesImage(t.Image) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			targets = append(targets, t)
+		}
+	}
+	return targets, nil
+}
+
+// resolveImageTargets は選択子に一致するイメージを列挙します（名前は repository:tag で照合）
+func (s *Shell) resolveImageTargets(selectors []targetSelector) ([]dockerTarget, error) {
+	if !s.shellExecutor.IsDockerAvailable() {
+		return nil, fmt.Errorf(i18n.T("docker.not_available"))
+	}
+	names, filters := splitSelectors(selectors)
+	args := []string{"images", "--format", "{{.ID}}\t{{.Repository}}:{{.Tag}}"}
+	for _, sel := range filters {
+		if sel.Kind != selectLabel {
+			return nil, fmt.Errorf(i18n.T("selector.unsupported_for_images"), sel.Raw)
+		}
+		args = append(args, "--filter", "label="+sel.Value)
+	}
+	output, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("selector.resolve_failed"), err)
+	}
+
+	var targets []dockerTarget
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 2 {
+			continue
+		}
+		t := dockerTarget{ID: parts[0], Name: parts[1], Image: parts[1]}
+		// <none>:<none> のイメージは ID で扱う
+		if strings.HasPrefix(t.Name, "<none>") {
+			t.Name = t.ID
+		}
+		if matchesAnyName(names, t) || matchesAnyImageName(names, t.Image) {
+			targets = append(targets, t)
+		}
+	}
+	return targets, nil
+}
+
+// matchesAnyImageName は nginx* のようなタグを省略したパターンでもイメージに一致させます
+func matchesAnyImageName(names []targetSelector, image string) bool {
+	for _, sel := range names {
+		switch sel.Kind {
+		case selectName:
+			if image == sel.Value || image == sel.Value+":latest" {
+				return true
+			}
+		case selectGlob:
+			if sel.matchesImage(image) {
+				return true
+			}
+		}
+	}
+	return false
+}
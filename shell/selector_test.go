@@ -0,0 +1,154 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTargetSelectors(t *testing.T) {
+	options := []optionSpec{
+		{Short: "-f"},
+		{Name: "--signal", Type: valueString},
+		labelOption,
+	}
+	type sel struct {
+		Kind  selectorKind
+		Value string
+		Raw   string
+	}
+	tests := []struct {
+		name    string
+		raw     []string
+		want    []sel
+		wantErr bool
+	}{
+		{
+			name: "plain names",
+			raw:  []string{"web", "api"},
+			want: []sel{{selectName, "web", "web"}, {selectName, "api", "api"}},
+		},
+		{
+			name: "wildcard is a glob",
+			raw:  []string{"web-*"},
+			want: []sel{{selectGlob, "web-*", "web-*"}},
+		},
+		{
+			name: "name= is a glob",
+			raw:  []string{"name=web-?"},
+			want: []sel{{selectGlob, "web-?", "name=web-?"}},
+		},
+		{
+			name: "slashes make a regex",
+			raw:  []string{`/web-\d+/`},
+			want: []sel{{selectRegex, `/web-\d+/`, `/web-\d+/`}},
+		},
+		{
+			name:    "invalid regex",
+			raw:     []string{"/web-(/"},
+			wantErr: true,
+		},
+		{
+			name: "key=value selectors",
+			raw:  []string{"status=exited", "image=nginx*", "project=shop", "label=env=dev"},
+			want: []sel{
+				{selectStatus, "exited", "status=exited"},
+				{selectImage, "nginx*", "image=nginx*"},
+				{selectProject, "shop", "project=shop"},
+				{selectLabel, "env=dev", "label=env=dev"},
+			},
+		},
+		{
+			name:    "key without a value",
+			raw:     []string{"status="},
+			wantErr: true,
+		},
+		{
+			name: "unknown key stays a name",
+			raw:  []string{"foo=bar"},
+			want: []sel{{selectName, "foo=bar", "foo=bar"}},
+		},
+		{
+			name: "--label with a separate value",
+			raw:  []string{"--label", "env=dev", "web"},
+			want: []sel{{selectLabel, "env=dev", "label=env=dev"}, {selectName, "web", "web"}},
+		},
+		{
+			name: "--label=",
+			raw:  []string{"--label=tier"},
+			want: []sel{{selectLabel, "tier", "--label=tier"}},
+		},
+		{
+			name:    "--label without a value",
+			raw:     []string{"web", "--label"},
+			wantErr: true,
+		},
+		{
+			name: "flags and option values are skipped",
+			raw:  []string{"-f", "--signal", "HUP", "web", "--signal=TERM"},
+			want: []sel{{selectName, "web", "web"}},
+		},
+		{
+			name: "a lone dash is a name",
+			raw:  []string{"-"},
+			want: []sel{{selectName, "-", "-"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTargetSelectors(tt.raw, options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTargetSelectors(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var gotSels []sel
+			for _, s := range got {
+				gotSels = append(gotSels, sel{s.Kind, s.Value, s.Raw})
+			}
+			if !reflect.DeepEqual(gotSels, tt.want) {
+				t.Errorf("parseTargetSelectors(%q) = %v, want %v", tt.raw, gotSels, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetSelectorMatches(t *testing.T) {
+	target := dockerTarget{ID: "0123456789ab", Name: "shop-web-1", Image: "library/nginx:1.25"}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"shop-web-1", true},
+		{"shop-web", false},
+		{"012", true}, // ID の前方一致は3文字から
+		{"01", false},
+		{"shop-*", true},
+		{"shop-web-[0-9]", true},
+		{"shop-web-[!0-9]", false},
+		{"*-api-*", false},
+		{`/web-\d$/`, true},
+		{`/^web/`, false},
+		{"image=library/nginx", true},
+		{"image=*nginx*", true},
+		{"image=nginx", false},
+		{"image=library/nginx:1.24", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := parseTargetSelector(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bool
+			if sel.Kind == selectImage {
+				got = sel.matchesImage(target.Image)
+			} else {
+				got = sel.matchesName(target)
+			}
+			if got != tt.want {
+				t.Errorf("%q matches %v = %v, want %v", tt.selector, target, got, tt.want)
+			}
+		})
+	}
+}
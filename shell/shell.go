@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"docsh/config"
@@ -30,6 +31,9 @@ type Shell struct {
 	commandParser   parser.CommandParser
 	shellExecutor   executor.ShellExecutor
 	dataPath        string
+	registry        *commandRegistry
+	completion      *completionCache
+	containerCwd    *containerLocation // コンテナ内ブラウズ中の位置（ホストでは nil）
 	teaProgram      *tea.Program
 	pendingExternal func() error
 }
@@ -49,7 +53,7 @@ func NewShell(cfg *config.Config, dataPath string) *Shell {
 
 	// マッピングデータを読み込み
 	if err := mappingEngine.LoadMappings(); err != nil {
-		fmt.Printf(i18n.T("shell.config_load_warning")+"\n", err)
+		fmt.Fprintf(os.Stderr, i18n.T("shell.config_load_warning")+"\n", err)
 	}
 
 	shell := &Shell{
@@ -60,7 +64,10 @@ func NewShell(cfg *config.Config, dataPath string) *Shell {
 		commandParser: commandParser,
 		shellExecutor: shellExecutor,
 		dataPath:      dataPath,
+		registry:      newCommandRegistry(),
 	}
+	registerBuiltinCommands(shell.registry)
+	shell.registry.attachMappings(mappingEngine.GetAllMappings())
 
 	// Windows環境の初期化
 	shell.initializeWindowsEnvironment()
@@ -70,8 +77,13 @@ func NewShell(cfg *config.Config, dataPath string) *Shell {
 
 	// 設定ファイルを読み込み
 	if err := cfg.LoadConfigFile(); err != nil {
-		fmt.Printf(i18n.T("shell.config_load_warning")+"\n", err)
+		fmt.Fprintf(os.Stderr, i18n.T("shell.config_load_warning")+"\n", err)
 	}
+	for _, warning := range cfg.Warnings {
+		fmt.Fprintf(os.Stderr, i18n.T("shell.config_load_warning")+"\n", warning)
+	}
+	shell.registry.syncAliases(cfg.Aliases)
+	shell.completion = newShellCompletionCache(shell, time.Duration(cfg.CompletionCacheTTL)*time.Second)
 
 	return shell
 }
@@ -79,8 +91,9 @@ func NewShell(cfg *config.Config, dataPath string) *Shell {
 func (s *Shell) Start() error {
 	// 設定ファイルを再読み込み
 	if err := s.config.LoadConfigFile(); err != nil {
-		fmt.Printf(i18n.T("shell.config_load_warning"), err)
+		fmt.Fprintf(os.Stderr, i18n.T("shell.config_load_warning")+"\n", err)
 	}
+	s.registry.syncAliases(s.config.Aliases)
 
 	// 起動バナーを表示
 	if s.config.BannerEnabled {
@@ -90,6 +103,13 @@ func (s *Shell) Start() error {
 
 	fmt.Print(i18n.T("app.docker_only_welcome"))
 
+	// 補完データを先行取得し、docker events で更新を受け取る
+	s.completion.prefetch(cacheContainers, cacheRunningContainers, cacheImages, cacheCompose, cacheContainerDetails, cacheImageDetails, cacheComposeFilesPrefix+s.getCurrentDir())
+	if s.config.CompletionWatchEvents {
+		s.completion.watchEvents()
+	}
+	defer s.completion.stop()
+
 	// Bubble Tea REPL を再起動可能にするループ
 	for {
 		// REPL起動
@@ -160,9 +180,27 @@ func (s *Shell) ExecuteCommand(input string) error {
 }
 
 func (s *Shell) executeCommand(input string) error {
-	// エイリアス展開
-	if s.config != nil {
-		input = s.config.ExpandAlias(input)
+	return s.dispatchCommand(input, map[string]bool{})
+}
+
+// dispatchCommand はレジストリを引いて、内蔵・ライフサイクル・マッピング・エイリアスを
+// 対話モード/直接実行の区別なく同じ経路で実行します
+func (s *Shell) dispatchCommand(input string, expanding map[string]bool) error {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// エイリアス展開（自己参照の無限展開は expanding で防止）
+	if spec, ok := s.registry.resolve(fields[0]); ok && spec.Kind == kindAlias && !expanding[spec.Name] {
+		expanding[spec.Name] = true
+		expanded := append(strings.Fields(spec.AliasOf), fields[1:]...)
+		// top のように内蔵コマンド名のエイリアスでも、引数を省略すれば現在のコンテナを対象にする
+		if target := s.contextTarget(fields[0], fields[1:]); target != "" {
+			expanded = append(expanded, target)
+			fmt.Printf(i18n.T("context.using")+"\n", target)
+		}
+		return s.dispatchCommand(strings.Join(expanded, " "), expanding)
 	}
 
 	// コマンドをパース
@@ -174,180 +212,36 @@ func (s *Shell) executeCommand(input string) error {
 		return nil
 	}
 
-	command := parsedCmd.Command
-	args := parsedCmd.Args
-
-	// Docker専用シェルの内蔵コマンドのみ処理
-	switch command {
-	case "cd":
-		return s.changeDirectory(args)
-	case "pwd":
-		fmt.Println(s.getCurrentDir())
-		return nil
-	case "alias":
-		return s.handleAliasCommand(args)
-	case "theme":
-		return s.handleThemeCommand(args)
-	case "lang":
-		return s.handleLangCommand(args)
-	case "config":
-		if len(args) > 0 {
-			switch args[0] {
-			case "show":
-				s.showConfig()
-			default:
-				fmt.Printf(i18n.T("config.unknown_command")+"\n", args[0])
-			}
-		} else {
-			fmt.Println(i18n.T("config.usage"))
-		}
-		return nil
-	case "mapping":
-		return s.handleMappingCommand(args)
-	case "help":
-		s.showHelp()
-		return nil
-	case "version":
-		fmt.Println(i18n.T("app.docker_only_version"))
-		return nil
-	case "htop":
-		// Bubble Tea ベースのTUIモニターを起動
-		return s.launchContainerMonitor()
-	case "project":
-		return s.handleProjectCommand(args)
-	case "login":
-		if len(args) == 0 {
-			return fmt.Errorf(i18n.T("docker.container_name_required"))
-		}
-		return s.enterContainer(args[0])
-	case "ps":
-		// カスタム: ps --by-project
-		if parsedCmd.Options["by-project"] == "true" {
-			return s.psByProject()
-		}
-		// それ以外は既存のデフォルト処理に倣って実行
-		isStreaming := isStreamingCommand(parsedCmd)
-		if isStreaming {
-			return s.executeStreamingCommandDirectly(parsedCmd)
-		}
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		result, err := s.shellExecutor.Execute(ctx, parsedCmd)
-		if err != nil {
-			fmt.Printf("❌ %s\n", result.Error)
-			fmt.Println(i18n.T("app.docker_only_available_commands"))
-			fmt.Println(i18n.T("app.docker_only_commands_list"))
-			fmt.Println(i18n.T("app.docker_only_mapping_help"))
-			return nil
-		}
-		if result.Output != "" {
-			fmt.Print(result.Output)
-		}
-		if result.Mapping != nil {
-			fmt.Printf("✅ %s -> %s\n", result.Mapping.LinuxCommand, result.Mapping.DockerCommand)
-		}
-		return nil
-	case "clear", "cls":
-		fmt.Print("\033[2J\033[H")
-		return nil
-	// Docker lifecycle commands
-	case "pull":
-		if len(args) == 0 {
-			return fmt.Errorf(i18n.T("docker.image_name_required"))
-		}
-		return s.pullImage(args[0])
-	case "start":
-		if len(args) == 0 {
-			return fmt.Errorf(i18n.T("docker.container_name_required"))
-		}
-		return s.startContainer(args[0])
-	case "exec":
-		if len(args) < 2 {
-			return fmt.Errorf(i18n.T("docker.container_name_required") + " and " + i18n.T("docker.command_required"))
-		}
-		return s.execInContainer(args[0], args[1:])
-	case "stop":
-		if len(args) == 0 {
-			return fmt.Errorf(i18n.T("docker.container_name_required"))
-		}
-		return s.stopContainer(args[0])
-	case "rm":
-		if len(args) == 0 {
-			return fmt.Errorf(i18n.T("docker.container_name_required"))
-		}
-		// Check for --force flag
-		force := false
-		containerName := args[0]
-		if len(args) > 1 {
-			for _, arg := range args[1:] {
-				if arg == "--force" || arg == "-f" {
-					force = true
-					break
-				}
-			}
-		}
-		return s.removeContainer(containerName, force)
-	case "rmi":
-		if len(args) == 0 {
-			return fmt.Errorf(i18n.T("docker.image_name_required"))
-		}
-		// Check for --force flag
-		force := false
-		imageName := args[0]
-		if len(args) > 1 {
-			for _, arg := range args[1:] {
-				if arg == "--force" || arg == "-f" {
-					force = true
-					break
-				}
-			}
-		}
-		return s.removeImage(imageName, force)
-	default:
-		// Docker専用シェルモードでコマンド実行
-		// ストリーミングコマンドの場合は特別な処理を行う
-		isStreaming := isStreamingCommand(parsedCmd)
-		if isStreaming {
-			return s.executeStreamingCommandDirectly(parsedCmd)
-		}
-
-		// 通常のコマンド実行
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		result, err := s.shellExecutor.Execute(ctx, parsedCmd)
-		if err != nil {
-			// Docker専用シェルのエラーメッセージを表示
-			fmt.Printf("❌ %s\n", result.Error)
-			fmt.Println(i18n.T("app.docker_only_available_commands"))
-			fmt.Println(i18n.T("app.docker_only_commands_list"))
-			fmt.Println(i18n.T("app.docker_only_mapping_help"))
-			return nil
-		}
-
-		// 結果を表示
-		if result.Output != "" {
-			fmt.Print(result.Output)
-		}
-
-		// マッピング情報を表示
-		if result.Mapping != nil {
-			fmt.Printf("✅ %s -> %s\n", result.Mapping.LinuxCommand, result.Mapping.DockerCommand)
-		}
+	// コンテナ内ブラウズ中（または container:/path 指定）のファイル参照はコンテナ内で実行
+	if handled, err := s.runContainerFSCommand(parsedCmd); handled {
+		return err
+	}
 
-		return nil
+	spec, ok := s.registry.lookup(parsedCmd.Command)
+	if !ok || spec.Handler == nil {
+		// 未登録のコマンドは Docker 専用モードの既定処理へ
+		return s.runMappedCommand(parsedCmd)
 	}
+	// --tail 10 のように値を取るオプションをメタデータに従って結び付ける
+	bindOptionValues(parsedCmd, spec.allOptions())
+	// コンテナを省略した場合は use で設定した現在のコンテナを補う
+	s.applyContainerContext(spec, parsedCmd)
+	return spec.Handler(s, parsedCmd)
 }
 
 // launchContainerMonitor は TUI のコンテナモニターを起動
-func (s *Shell) launchContainerMonitor() error {
-	// 依存: docker が必要
-	if !s.shellExecutor.IsDockerAvailable() {
+func (s *Shell) launchContainerMonitor(args []string) error {
+	opts, err := s.parseMonitorOptions(args, htopOptions, "monitor")
+	if err != nil {
+		return err
+	}
+	// 依存: docker が必要（記録の再生では不要）
+	if opts.Replay == "" && !s.shellExecutor.IsDockerAvailable() {
 		return fmt.Errorf(i18n.T("docker.not_available"))
 	}
 
 	// TUI 実行
-	program := tui.NewMonitorProgram()
+	program := tui.NewMonitorProgram(opts)
 	if err := program(); err != nil {
 		return fmt.Errorf("monitor ui error: %w", err)
 	}
@@ -367,7 +261,29 @@ func (s *Shell) handleAliasCommand(args []string) error {
 
 	// alias name=command の形式で新しいエイリアスを設定
 	aliasString := strings.Join(args, " ")
-	return s.config.ParseAlias(aliasString)
+	if err := s.config.ParseAlias(aliasString); err != nil {
+		return err
+	}
+	s.registry.syncAliases(s.config.Aliases)
+	return nil
+}
+
+// handleUnaliasCommand は unalias <name>... でエイリアスを削除します
+func (s *Shell) handleUnaliasCommand(args []string) error {
+	if s.config == nil {
+		return fmt.Errorf(i18n.T("config.not_initialized"))
+	}
+	if len(args) == 0 {
+		return fmt.Errorf(i18n.T("alias.unalias_usage"))
+	}
+	for _, name := range args {
+		if !s.config.RemoveAlias(name) {
+			return fmt.Errorf(i18n.T("alias.alias_not_found"), name)
+		}
+		fmt.Printf(i18n.T("alias.alias_removed")+"\n", name)
+	}
+	s.registry.syncAliases(s.config.Aliases)
+	return nil
 }
 
 func (s *Shell) handleThemeCommand(args []string) error {
@@ -527,8 +443,8 @@ func (s *Shell) listAllMappings() {
 		categoryMappings, _ := s.mappingEngine.ListByCategory(category)
 		for _, mapping := range categoryMappings {
 			fmt.Printf("  %s -> %s\n", mapping.LinuxCommand, mapping.DockerCommand)
-			if mapping.Description != "" {
-				fmt.Printf("    %s\n", mapping.Description)
+			if desc := mapping.DescriptionFor(i18n.GetCurrentLanguage()); desc != "" {
+				fmt.Printf("    %s\n", desc)
 			}
 		}
 		fmt.Println()
@@ -596,20 +512,20 @@ func (s *Shell) showMapping(command string) error {
 	fmt.Printf("Linux Command: %s\n", mapping.LinuxCommand)
 	fmt.Printf("Docker Command: %s\n", mapping.DockerCommand)
 	fmt.Printf("Category: %s\n", i18n.T("categories."+mapping.Category))
-	fmt.Printf("%s: %s\n", i18n.T("help.description"), mapping.Description)
+	fmt.Printf("%s: %s\n", i18n.T("help.description"), mapping.DescriptionFor(i18n.GetCurrentLanguage()))
 	fmt.Printf("Linux Example: %s\n", mapping.LinuxExample)
 	fmt.Printf("Docker Example: %s\n", mapping.DockerExample)
 
-	if len(mapping.Notes) > 0 {
+	if notes := mapping.NotesFor(i18n.GetCurrentLanguage()); len(notes) > 0 {
 		fmt.Printf("\n%s:\n", i18n.T("help.notes"))
-		for _, note := range mapping.Notes {
+		for _, note := range notes {
 			fmt.Printf("  - %s\n", note)
 		}
 	}
 
-	if len(mapping.Warnings) > 0 {
+	if warnings := mapping.WarningsFor(i18n.GetCurrentLanguage()); len(warnings) > 0 {
 		fmt.Printf("\n%s:\n", i18n.T("help.warnings"))
-		for _, warning := range mapping.Warnings {
+		for _, warning := range warnings {
 			fmt.Printf("  ⚠️  %s\n", warning)
 		}
 	}
@@ -641,7 +557,7 @@ func isStreamingCommand(parsedCmd *parser.ParsedCommand) bool {
 		}
 	} else if parsedCmd.Command == "docker" {
 		// 既にDockerコマンドの場合
-		dockerCmd = append([]string{"docker"}, parsedCmd.Args...)
+		dockerCmd = append([]string{"docker"}, parsedCmd.RawArgs...)
 	} else {
 		// その他のコマンドの場合、基本的にストリーミングではない
 		return false
@@ -686,58 +602,6 @@ func isStreamingCommand(parsedCmd *parser.ParsedCommand) bool {
 	return false
 }
 
-// showHelp はヘルプを表示します
-func (s *Shell) showHelp() {
-	fmt.Println(i18n.T("commands.docker_only_help_title"))
-	fmt.Println(i18n.T("commands.docker_only_help_description"))
-	fmt.Println()
-	fmt.Println(i18n.T("commands.docker_only_mappings_title"))
-	fmt.Println(i18n.T("examples.basic_usage"))
-	fmt.Println(i18n.T("commands.examples_ls"))
-	fmt.Println(i18n.T("commands.examples_ps"))
-	fmt.Println(i18n.T("commands.examples_kill"))
-	fmt.Println(i18n.T("commands.examples_rm"))
-	fmt.Println(i18n.T("commands.examples_tail"))
-	fmt.Println(i18n.T("commands.examples_log"))
-	fmt.Println(i18n.T("commands.examples_login"))
-	fmt.Println()
-	// Dockerコマンドの列挙は非表示（設計に合わせて省略）
-	fmt.Println(i18n.T("commands.lifecycle_header_2"))
-	fmt.Println(i18n.T("commands.lifecycle_pull_2"))
-	fmt.Println(i18n.T("commands.lifecycle_start_2"))
-	fmt.Println(i18n.T("commands.lifecycle_stop_2"))
-	fmt.Println(i18n.T("commands.lifecycle_exec_2"))
-	fmt.Println(i18n.T("commands.lifecycle_login_2"))
-	fmt.Println(i18n.T("commands.lifecycle_rm_2"))
-	fmt.Println(i18n.T("commands.lifecycle_rmi_2"))
-	fmt.Println(i18n.T("commands.lifecycle_log_2"))
-	fmt.Println(i18n.T("commands.lifecycle_tailf_2"))
-	fmt.Println(i18n.T("commands.lifecycle_top_2"))
-	fmt.Println(i18n.T("commands.lifecycle_htop_2"))
-	fmt.Println(i18n.T("commands.lifecycle_streaming_note_2"))
-	fmt.Println()
-	// 内蔵コマンド（最後に表示）
-	// Docker Compose lifecycle（設計どおり）
-	fmt.Println(i18n.T("commands.compose_lifecycle_header"))
-	fmt.Println("  " + i18n.T("commands.compose_project_ps_line"))
-	fmt.Println("  " + i18n.T("commands.compose_project_service_start_line"))
-	fmt.Println("  " + i18n.T("commands.compose_project_service_logs_line"))
-	fmt.Println("  " + i18n.T("commands.compose_project_service_restart_line"))
-	fmt.Println("  " + i18n.T("commands.compose_project_service_stop_line"))
-	fmt.Println("  " + i18n.T("commands.ps_by_project_line"))
-	fmt.Println()
-	// 内蔵コマンド
-	fmt.Println(i18n.T("commands.docker_only_builtin_commands_title"))
-	fmt.Println("  help                    " + i18n.T("help.usage"))
-	fmt.Println("  " + i18n.T("commands.mapping_help_2"))
-	fmt.Println("  " + i18n.T("commands.alias_help_2"))
-	fmt.Println("  " + i18n.T("commands.theme_help_2"))
-	fmt.Println("  " + i18n.T("commands.config_help_2"))
-	fmt.Println("  " + i18n.T("commands.exit_help_2"))
-	fmt.Println()
-	fmt.Println(i18n.T("commands.docker_only_note_title") + " " + i18n.T("commands.docker_only_note_message"))
-}
-
 // executeStreamingCommandDirectly はgo-promptをバイパスしてストリーミングコマンドを直接実行します
 func (s *Shell) executeStreamingCommandDirectly(parsedCmd *parser.ParsedCommand) error {
 	// マッピングを解決
@@ -753,14 +617,19 @@ func (s *Shell) executeStreamingCommandDirectly(parsedCmd *parser.ParsedCommand)
 			return err
 		}
 		dockerCmd = strings.Fields(mapping.DockerCommand)
+		dockerCmd = append(dockerCmd, optionArgs(parsedCmd.Options, "f")...)
 		dockerCmd = append(dockerCmd, parsedCmd.Args...)
 	} else if parsedCmd.Command == "docker" {
 		// 直接Dockerコマンド
-		dockerCmd = append([]string{"docker"}, parsedCmd.Args...)
+		dockerCmd = append([]string{"docker"}, parsedCmd.RawArgs...)
 	} else {
 		return fmt.Errorf("unsupported streaming command: %s", parsedCmd.Command)
 	}
+	return s.streamDockerCommand(dockerCmd, mapping)
+}
 
+// streamDockerCommand はストリーミング出力の Docker コマンドを、シグナルや exit 入力で停止できる形で実行します
+func (s *Shell) streamDockerCommand(dockerCmd []string, mapping *engine.CommandMapping) error {
 	fmt.Printf(i18n.T("app.executing")+"\n", strings.Join(dockerCmd, " "))
 	if mapping != nil {
 		fmt.Printf(i18n.T("app.mapping_applied")+"\n", mapping.LinuxCommand, mapping.DockerCommand)
@@ -957,6 +826,19 @@ func (s *Shell) watchForSignals(ctx context.Context, terminationChan chan string
 	}()
 }
 
+// serviceContext は常駐コマンド（metrics serve など）の終了用コンテキストです。
+// SIGINT と SIGTERM で終了します。対話用の watchForSignals と違い親プロセスの変化や
+// 保留中のシグナルは見ないため、nohup や systemd の配下でも動き続けます
+func serviceContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// stdinIsTerminal は標準入力が端末か判定します。パイプや /dev/null のときは入力を監視しません
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // watchForStdinExit は標準入力から exit コマンドを監視します
 func (s *Shell) watchForStdinExit(ctx context.Context, terminationChan chan string) {
 
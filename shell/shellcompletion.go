@@ -0,0 +1,104 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"docsh/i18n"
+)
+
+// bashCompletionScript は bash 用の補完スクリプトです。
+// COMP_WORDBREAKS の ":" で単語が分割されないよう、COMP_LINE から単語を組み立て直します。
+const bashCompletionScript = `# bash completion for docsh
+# source <(docsh completion bash)
+_docsh_complete() {
+    local line="${COMP_LINE:0:COMP_POINT}"
+    local -a words
+    read -r -a words <<< "${line}"
+    [[ "${line}" == *" " ]] && words+=("")
+    local cur="${words[${#words[@]}-1]}"
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$("${words[0]}" __complete "${words[@]:1}" 2>/dev/null | cut -f1)" -- "${cur}"))
+    if [[ "${cur}" == *:* && "${COMP_WORDBREAKS}" == *:* ]]; then
+        local prefix="${cur%"${cur##*:}"}"
+        COMPREPLY=("${COMPREPLY[@]#"${prefix}"}")
+    fi
+}
+complete -o default -F _docsh_complete docsh
+`
+
+// zshCompletionScript は zsh 用の補完スクリプトです（説明文付き）
+const zshCompletionScript = `#compdef docsh
+# source <(docsh completion zsh)
+_docsh() {
+    local -a candidates
+    local line text desc
+    for line in "${(@f)$("${words[1]}" __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -z "${line}" ]] && continue
+        text="${line%%$'\t'*}"
+        desc=""
+        [[ "${line}" == *$'\t'* ]] && desc="${line#*$'\t'}"
+        candidates+=("${text//:/\\:}:${desc}")
+    done
+    _describe 'docsh' candidates
+}
+compdef _docsh docsh
+`
+
+// fishCompletionScript は fish 用の補完スクリプトです（fish はタブ区切りの説明文をそのまま扱えます）
+const fishCompletionScript = `# fish completion for docsh
+# docsh completion fish | source
+function __docsh_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    $tokens[1] __complete $tokens[2..-1] $current 2>/dev/null
+end
+complete -c docsh -f -a '(__docsh_complete)'
+`
+
+// oneShotCompletionWait は docsh __complete で Docker の一覧取得を待つ最大時間です
+const oneShotCompletionWait = 5 * time.Second
+
+// CompletionScript は指定されたシェル用の補完スクリプトを返します
+func CompletionScript(shellName string) (string, error) {
+	switch shellName {
+	case "bash":
+		return bashCompletionScript, nil
+	case "zsh":
+		return zshCompletionScript, nil
+	case "fish":
+		return fishCompletionScript, nil
+	default:
+		return "", fmt.Errorf(i18n.T("completion.unsupported_shell"), shellName)
+	}
+}
+
+// CompleteWords は docsh に渡された単語列（最後の要素が入力中の単語）の補完候補を返します。
+// 外部シェルからの補完（docsh __complete）用で、REPL と同じ Complete を利用します。
+func (s *Shell) CompleteWords(words []string) []Suggest {
+	// 1回限りの実行なので、初回取得を待ってから候補を返す
+	s.completion.wait = oneShotCompletionWait
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	if len(words) == 1 {
+		// REPL と異なり、外部シェルでは 0〜1 文字でも候補を返す
+		return s.completeCommands(words[0])
+	}
+	return s.Complete(strings.Join(words, " "))
+}
+
+// FormatCompletions は __complete の出力形式（1行1候補、説明はタブ区切り）に整形します
+func FormatCompletions(suggests []Suggest) string {
+	var b strings.Builder
+	for _, sg := range suggests {
+		b.WriteString(sg.Text)
+		if sg.Description != "" {
+			b.WriteString("\t")
+			b.WriteString(strings.ReplaceAll(sg.Description, "\n", " "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,738 @@
+package shell
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"docsh/i18n"
+	"docsh/internal/parser"
+)
+
+// transferOptions は cp / mv が受け付けるオプションです
+var transferOptions = []optionSpec{
+	{Name: "--recursive", Short: "-r", DescKey: "help.option_descriptions.recursive"},
+	{Name: "--preserve", Short: "-p", DescKey: "help.option_descriptions.preserve"},
+}
+
+// progressThreshold を超える転送では進捗を表示します
+const progressThreshold = 1 << 20
+
+// transferEndpoint は転送元・転送先の1つです（Container が空ならホストのパス）
+type transferEndpoint struct {
+	Container string
+	Path      string
+}
+
+func parseEndpoint(arg string) transferEndpoint {
+	if container, p, ok := splitContainerPath(arg); ok {
+		if p == "" {
+			p = "/"
+		}
+		return transferEndpoint{Container: container, Path: p}
+	}
+	return transferEndpoint{Path: arg}
+}
+
+func (e transferEndpoint) String() string {
+	if e.Container == "" {
+		return e.Path
+	}
+	return e.Container + ":" + e.Path
+}
+
+func (e transferEndpoint) base() string {
+	if e.Container == "" {
+		return filepath.Base(filepath.Clean(e.Path))
+	}
+	return path.Base(path.Clean(e.Path))
+}
+
+func (e transferEndpoint) join(name string) transferEndpoint {
+	if e.Container == "" {
+		return transferEndpoint{Path: filepath.Join(e.Path, name)}
+	}
+	return transferEndpoint{Container: e.Container, Path: path.Join(e.Path, name)}
+}
+
+func (e transferEndpoint) dir() transferEndpoint {
+	if e.Container == "" {
+		return transferEndpoint{Path: filepath.Dir(filepath.Clean(e.Path))}
+	}
+	return transferEndpoint{Container: e.Container, Path: path.Dir(path.Clean(e.Path))}
+}
+
+// hasTransferFlag は生の引数に指定のフラグ（-rp のような結合形式を含む）があるかを返します
+func hasTransferFlag(raw []string, opt optionSpec) bool {
+	short := strings.TrimPrefix(opt.Short, "-")
+	for _, arg := range raw {
+		if arg == opt.Name || arg == opt.Short {
+			return true
+		}
+		if short != "" && len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && strings.Contains(arg[1:], short) {
+			return true
+		}
+	}
+	return false
+}
+
+// runTransfer は cp / mv を処理します。container:path をどちら側にも指定でき、
+// コンテナ間の転送はホストを経由した tar ストリームで行います。
+func (s *Shell) runTransfer(parsedCmd *parser.ParsedCommand, move bool) error {
+	raw := parsedCmd.RawArgs
+	recursive := hasTransferFlag(raw, transferOptions[0]) || hasTransferFlag(raw, optionSpec{Short: "-R"})
+	preserve := hasTransferFlag(raw, transferOptions[1])
+	operands := positionalArgs(raw, transferOptions)
+
+	hasContainer := false
+	for _, arg := range operands {
+		if _, _, ok := splitContainerPath(arg); ok {
+			hasContainer = true
+		}
+	}
+	// 従来の mv <container> <src> <dest>（コンテナ内での移動）
+	if move && !hasContainer && len(operands) == 3 && !hostPathExists(operands[0]) {
+		container := operands[0]
+		src := transferEndpoint{Container: container, Path: operands[1]}
+		dest := transferEndpoint{Container: container, Path: operands[2]}
+		return s.transferInContainer("mv", []transferEndpoint{src}, dest, false, preserve)
+	}
+	if len(operands) < 2 {
+		return fmt.Errorf(i18n.T("transfer.usage"), parsedCmd.Command)
+	}
+	if !hasContainer {
+		return fmt.Errorf(i18n.T("transfer.host_only"), parsedCmd.Command)
+	}
+	if !s.shellExecutor.IsDockerAvailable() {
+		return fmt.Errorf(i18n.T("docker.not_available"))
+	}
+
+	dest := parseEndpoint(operands[len(operands)-1])
+	var sources []transferEndpoint
+	for _, arg := range operands[:len(operands)-1] {
+		matches, err := s.expandEndpoint(parseEndpoint(arg))
+		if err != nil {
+			return err
+		}
+		sources = append(sources, matches...)
+	}
+
+	// 末尾が / のホストのパスは、存在しなければディレクトリとして作成する
+	destIsDir := s.endpointKind(dest) == "d" || (dest.Container == "" && strings.HasSuffix(dest.Path, string(filepath.Separator)))
+	if len(sources) > 1 && !destIsDir {
+		return fmt.Errorf(i18n.T("transfer.dest_not_directory"), dest)
+	}
+
+	// 同じコンテナ内なら cp / mv をそのままコンテナで実行
+	if sameContainer(sources, dest) {
+		return s.transferInContainer(parsedCmd.Command, sources, dest, recursive, preserve)
+	}
+
+	for _, src := range sources {
+		kind := s.endpointKind(src)
+		if kind == "" {
+			return fmt.Errorf(i18n.T("transfer.not_found"), src)
+		}
+		if kind == "d" && !recursive {
+			return fmt.Errorf(i18n.T("transfer.omitting_directory"), parsedCmd.Command, src)
+		}
+		target, name := dest, src.base()
+		if !destIsDir {
+			target, name = dest.dir(), dest.base()
+		}
+		if err := s.copyEndpoint(src, target, name, preserve); err != nil {
+			return err
+		}
+		if move {
+			if err := s.removeEndpoint(src); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hostPathExists はホストにパスが存在するかを返します
+func hostPathExists(p string) bool {
+	_, err := os.Lstat(p)
+	return err == nil
+}
+
+func sameContainer(sources []transferEndpoint, dest transferEndpoint) bool {
+	if dest.Container == "" {
+		return false
+	}
+	for _, src := range sources {
+		if src.Container != dest.Container {
+			return false
+		}
+	}
+	return true
+}
+
+// transferInContainer は同一コンテナ内の cp / mv を docker exec で実行します
+func (s *Shell) transferInContainer(command string, sources []transferEndpoint, dest transferEndpoint, recursive, preserve bool) error {
+	args := []string{"exec", dest.Container, command}
+	if command == "cp" && recursive {
+		args = append(args, "-r")
+	}
+	if command == "cp" && preserve {
+		args = append(args, "-p")
+	}
+	for _, src := range sources {
+		args = append(args, src.Path)
+	}
+	args = append(args, dest.Path)
+
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(i18n.T("transfer.failed"), strings.Join(cmd.Args, " "), err)
+	}
+	return nil
+}
+
+// expandEndpoint はワイルドカードを含むパスを展開します（コンテナ側はコンテナ内のシェルで展開）
+func (s *Shell) expandEndpoint(e transferEndpoint) ([]transferEndpoint, error) {
+	if !strings.ContainsAny(e.Path, "*?[") {
+		return []transferEndpoint{e}, nil
+	}
+	var matches []string
+	if e.Container == "" {
+		matches, _ = filepath.Glob(e.Path)
+	} else {
+		script := `for f in ` + quoteGlob(e.Path) + `; do [ -e "$f" ] && printf '%s\n' "$f"; done`
+		output, err := exec.Command("docker", "exec", e.Container, "sh", "-c", script).Output()
+		if err == nil {
+			for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+				if line != "" {
+					matches = append(matches, line)
+				}
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf(i18n.T("transfer.no_match"), e)
+	}
+	result := make([]transferEndpoint, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, transferEndpoint{Container: e.Container, Path: m})
+	}
+	return result, nil
+}
+
+// quoteGlob はパスを sh で展開できる形にします。ワイルドカード（* ? [ ]）以外はすべて
+// シングルクォートで囲むため、空白や ; $( ) ` を含むパスでもコマンドとして解釈されません
+func quoteGlob(pattern string) string {
+	var b, literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			b.WriteString("'" + strings.ReplaceAll(literal.String(), "'", `'\''`) + "'")
+			literal.Reset()
+		}
+	}
+	runes := []rune(pattern)
+	for i, r := range runes {
+		switch {
+		case r == '*' || r == '?' || r == '[' || r == ']':
+			flush()
+			b.WriteRune(r)
+		case (r == '!' || r == '^') && i > 0 && runes[i-1] == '[':
+			// 否定の [! ] はクォートすると文字として扱われるためそのまま出す
+			flush()
+			b.WriteRune(r)
+		default:
+			literal.WriteRune(r)
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// endpointKind はパスの種類を返します（"d": ディレクトリ、"f": その他、"": 存在しない）
+func (s *Shell) endpointKind(e transferEndpoint) string {
+	if e.Container == "" {
+		info, err := os.Stat(e.Path)
+		switch {
+		case err != nil:
+			return ""
+		case info.IsDir():
+			return "d"
+		default:
+			return "f"
+		}
+	}
+	script := `if [ -d "$1" ]; then echo d; elif [ -e "$1" ]; then echo f; fi`
+	output, err := exec.Command("docker", "exec", e.Container, "sh", "-c", script, "sh", e.Path).Output()
+	if err != nil {
+		// sh の無いイメージでは判定できないので、存在するものとして docker cp に任せる
+		return "f"
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// endpointSize は転送量の見積もり（バイト）を返します。不明な場合は 0 です。
+func (s *Shell) endpointSize(e transferEndpoint) int64 {
+	if e.Container == "" {
+		var total int64
+		_ = filepath.Walk(e.Path, func(_ string, info os.FileInfo, err error) error {
+			if err == nil && info.Mode().IsRegular() {
+				total += info.Size()
+			}
+			return nil
+		})
+		return total
+	}
+	output, err := exec.Command("docker", "exec", e.Container, "du", "-sk", e.Path).Output()
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return 0
+	}
+	kb, _ := strconv.ParseInt(fields[0], 10, 64)
+	return kb * 1024
+}
+
+// removeEndpoint は mv の転送後に転送元を削除します
+func (s *Shell) removeEndpoint(e transferEndpoint) error {
+	if e.Container == "" {
+		return os.RemoveAll(e.Path)
+	}
+	if out, err := exec.Command("docker", "exec", e.Container, "rm", "-rf", e.Path).CombinedOutput(); err != nil {
+		return fmt.Errorf(i18n.T("transfer.remove_failed"), e, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// copyEndpoint は src を targetDir/name にコピーします。
+// 転送元を tar ストリームとして読み、名前を付け替えながら転送先へ書き込みます。
+func (s *Shell) copyEndpoint(src, targetDir transferEndpoint, name string, preserve bool) error {
+	label := src.String() + " -> " + targetDir.join(name).String()
+	progress := newTransferProgress(label, s.endpointSize(src))
+
+	reader, wait, err := openTarSource(src)
+	if err != nil {
+		return err
+	}
+	sink, err := openTarSink(targetDir, preserve)
+	if err != nil {
+		reader.Close()
+		_ = wait()
+		return err
+	}
+
+	copyErr := copyTarStream(reader, sink, src.base(), name, progress)
+	reader.Close()
+	srcErr := wait()
+	sinkErr := sink.Close()
+	progress.finish(copyErr == nil && srcErr == nil && sinkErr == nil)
+
+	for _, err := range []error{srcErr, copyErr, sinkErr} {
+		if err != nil {
+			return fmt.Errorf(i18n.T("transfer.failed"), label, err)
+		}
+	}
+	return nil
+}
+
+// openTarSource は転送元を tar ストリームとして開きます。wait は転送元の終了を待ちます。
+func openTarSource(src transferEndpoint) (io.ReadCloser, func() error, error) {
+	if src.Container != "" {
+		cmd := exec.Command("docker", "cp", src.String(), "-")
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, nil, err
+		}
+		wait := func() error {
+			if err := cmd.Wait(); err != nil {
+				if msg := strings.TrimSpace(stderr.String()); msg != "" {
+					return errors.New(msg)
+				}
+				return err
+			}
+			return nil
+		}
+		return stdout, wait, nil
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := writeHostTar(pw, src.Path)
+		pw.CloseWithError(err)
+		done <- err
+	}()
+	return pr, func() error { return <-done }, nil
+}
+
+// writeHostTar はホストのファイル/ディレクトリを、ベース名を先頭にした tar として書き出します
+func writeHostTar(w io.Writer, root string) error {
+	tw := tar.NewWriter(w)
+	root = filepath.Clean(root)
+	parent := filepath.Dir(root)
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(parent, p)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// tarSink は tar のエントリを書き込む転送先です
+type tarSink interface {
+	WriteEntry(hdr *tar.Header, r io.Reader) error
+	Close() error
+}
+
+func openTarSink(targetDir transferEndpoint, preserve bool) (tarSink, error) {
+	if targetDir.Container == "" {
+		if err := os.MkdirAll(targetDir.Path, 0o755); err != nil {
+			return nil, err
+		}
+		return &hostTarSink{dir: targetDir.Path, preserve: preserve}, nil
+	}
+
+	args := []string{"cp"}
+	if preserve {
+		// -a: tar に含まれる UID/GID をそのまま使う
+		args = append(args, "-a")
+	}
+	args = append(args, "-", targetDir.String())
+	cmd := exec.Command("docker", args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &containerTarSink{cmd: cmd, stdin: stdin, tw: tar.NewWriter(stdin), stderr: &stderr}, nil
+}
+
+// containerTarSink は docker cp - container:dir の標準入力に tar を流し込みます
+type containerTarSink struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	tw     *tar.Writer
+	stderr *strings.Builder
+}
+
+func (c *containerTarSink) WriteEntry(hdr *tar.Header, r io.Reader) error {
+	if err := c.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(c.tw, r)
+	return err
+}
+
+func (c *containerTarSink) Close() error {
+	twErr := c.tw.Close()
+	c.stdin.Close()
+	if err := c.cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(c.stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return err
+	}
+	return twErr
+}
+
+// hostTarSink は tar をホストのディレクトリに展開します
+type hostTarSink struct {
+	dir      string
+	preserve bool
+	dirs     []*tar.Header // 更新時刻はディレクトリの中身を書き終えてから設定する
+}
+
+func (h *hostTarSink) WriteEntry(hdr *tar.Header, r io.Reader) error {
+	target, err := h.resolve(hdr.Name)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(hdr.Mode).Perm()
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		// 同名のリンクをたどって外のディレクトリの権限を変えないよう、ディレクトリ以外は置き換える
+		if err := removeNonDir(target); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(target, mode|0o700); err != nil {
+			return err
+		}
+		h.dirs = append(h.dirs, hdr)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		// 既存のファイルは削除してから作り直す（ハードリンク先の上書きを防ぐ）
+		if err := removeNonDir(target); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		// リンク先も転送先の中に限る（絶対パスや ../ で外を指すリンクは作らない）
+		if filepath.IsAbs(hdr.Linkname) || !h.contains(filepath.Join(filepath.Dir(target), filepath.FromSlash(hdr.Linkname))) {
+			return fmt.Errorf(i18n.T("transfer.unsafe_path"), hdr.Name+" -> "+hdr.Linkname)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := removeNonDir(target); err != nil {
+			return err
+		}
+		return os.Symlink(hdr.Linkname, target)
+	case tar.TypeLink:
+		linkTarget, err := h.resolve(hdr.Linkname)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := removeNonDir(target); err != nil {
+			return err
+		}
+		return os.Link(linkTarget, target)
+	default:
+		// デバイスファイルなどは展開しない
+		return nil
+	}
+
+	_ = os.Chmod(target, mode)
+	if h.preserve {
+		// 所有者の変更は権限が無ければ失敗するため、エラーは無視する
+		_ = os.Lchown(target, hdr.Uid, hdr.Gid)
+		_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+	}
+	return nil
+}
+
+// resolve はエントリ名を転送先の中のパスにします。転送先の外（../ や絶対パス）を指す名前と、
+// 途中にシンボリックリンクを含むパス（リンクをたどって外に書き込めてしまう）は拒否します
+func (h *hostTarSink) resolve(name string) (string, error) {
+	target := filepath.Join(h.dir, filepath.FromSlash(name))
+	if !h.contains(target) {
+		return "", fmt.Errorf(i18n.T("transfer.unsafe_path"), name)
+	}
+	rel, _ := filepath.Rel(h.dir, target)
+	p := h.dir
+	parts := strings.Split(rel, string(filepath.Separator))
+	for i, part := range parts {
+		p = filepath.Join(p, part)
+		info, err := os.Lstat(p)
+		if err != nil {
+			break // まだ無い部分から先は作成する
+		}
+		// 最後の要素自体がリンクなのは置き換えるので構わない
+		if info.Mode()&os.ModeSymlink != 0 && i < len(parts)-1 {
+			return "", fmt.Errorf(i18n.T("transfer.unsafe_path"), name)
+		}
+	}
+	return target, nil
+}
+
+// contains は path が転送先ディレクトリの中にあるか判定します
+func (h *hostTarSink) contains(path string) bool {
+	rel, err := filepath.Rel(h.dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// removeNonDir はディレクトリ以外の既存のパスを削除します（無ければ何もしません）
+func removeNonDir(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.IsDir() {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+func (h *hostTarSink) Close() error {
+	if h.preserve {
+		for i := len(h.dirs) - 1; i >= 0; i-- {
+			hdr := h.dirs[i]
+			target := filepath.Join(h.dir, filepath.FromSlash(hdr.Name))
+			_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+		}
+	}
+	return nil
+}
+
+// copyTarStream は tar の各エントリの先頭要素 oldName を newName に付け替えて転送先に書き込みます
+func copyTarStream(r io.Reader, sink tarSink, oldName, newName string, progress *transferProgress) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		hdr.Name = renameTarEntry(hdr.Name, oldName, newName)
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = renameTarEntry(hdr.Linkname, oldName, newName)
+		}
+		if err := sink.WriteEntry(hdr, progress.reader(tr)); err != nil {
+			return err
+		}
+	}
+}
+
+func renameTarEntry(name, oldName, newName string) string {
+	name = strings.TrimPrefix(name, "./")
+	if name == oldName || name == oldName+"/" {
+		return newName + strings.TrimPrefix(name, oldName)
+	}
+	if strings.HasPrefix(name, oldName+"/") {
+		return newName + name[len(oldName):]
+	}
+	return name
+}
+
+// transferProgress は転送量を数え、大きな転送では進捗を1行で表示します
+type transferProgress struct {
+	mu       sync.Mutex
+	label    string
+	total    int64
+	done     int64
+	start    time.Time
+	lastDraw time.Time
+	shown    bool
+}
+
+func newTransferProgress(label string, total int64) *transferProgress {
+	return &transferProgress{label: label, total: total, start: time.Now()}
+}
+
+func (p *transferProgress) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, p: p}
+}
+
+func (p *transferProgress) add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	if p.done < progressThreshold && p.total < progressThreshold {
+		return
+	}
+	if time.Since(p.lastDraw) < 100*time.Millisecond {
+		return
+	}
+	p.lastDraw = time.Now()
+	p.shown = true
+	p.draw()
+}
+
+func (p *transferProgress) draw() {
+	rate := float64(p.done) / math.Max(time.Since(p.start).Seconds(), 0.001)
+	if p.total > 0 {
+		pct := math.Min(100, float64(p.done)*100/float64(p.total))
+		fmt.Fprintf(os.Stderr, "\r⏳ %s  %s / %s  %3.0f%%  %s/s\033[K", p.label, formatBytes(p.done), formatBytes(p.total), pct, formatBytes(int64(rate)))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r⏳ %s  %s  %s/s\033[K", p.label, formatBytes(p.done), formatBytes(int64(rate)))
+}
+
+func (p *transferProgress) finish(ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.shown {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	if ok {
+		fmt.Printf(i18n.T("transfer.copied")+"\n", p.label, formatBytes(p.done), time.Since(p.start).Round(100*time.Millisecond))
+	}
+}
+
+type progressReader struct {
+	r io.Reader
+	p *transferProgress
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	if n > 0 {
+		pr.p.add(int64(n))
+	}
+	return n, err
+}
+
+// formatBytes はバイト数を 12.3MB のような表記にします
+func formatBytes(n int64) string {
+	if n < 1000 {
+		return fmt.Sprintf("%dB", n)
+	}
+	units := []string{"kB", "MB", "GB", "TB"}
+	v := float64(n)
+	idx := -1
+	for v >= 1000 && idx < len(units)-1 {
+		v /= 1000
+		idx++
+	}
+	return fmt.Sprintf("%.1f%s", v, units[idx])
+}
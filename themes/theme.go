@@ -12,6 +12,8 @@ type Theme struct {
 	Name   string
 	Prompt string
 	Colors map[string]string
+	// Services はログなどでサービスごとに順番に割り当てる色
+	Services []string
 }
 
 // 利用可能なテーマ
@@ -24,6 +26,7 @@ var themes = map[string]Theme{
 			"prompt":    "green",
 			"error":     "red",
 		},
+		Services: []string{"cyan", "yellow", "green", "magenta", "blue", "red"},
 	},
 	"minimal": {
 		Name:   "Minimal",
@@ -33,6 +36,7 @@ var themes = map[string]Theme{
 			"prompt":    "white",
 			"error":     "red",
 		},
+		Services: []string{"blue", "white", "cyan", "yellow"},
 	},
 	"robbyrussell": {
 		Name:   "Robbyrussell",
@@ -42,6 +46,7 @@ var themes = map[string]Theme{
 			"prompt":    "green",
 			"error":     "red",
 		},
+		Services: []string{"cyan", "green", "yellow", "magenta", "blue", "red"},
 	},
 	"agnoster": {
 		Name:   "Agnoster",
@@ -51,6 +56,7 @@ var themes = map[string]Theme{
 			"prompt":    "yellow",
 			"error":     "red",
 		},
+		Services: []string{"blue", "yellow", "magenta", "green", "cyan", "red"},
 	},
 	"pure": {
 		Name:   "Pure",
@@ -60,6 +66,7 @@ var themes = map[string]Theme{
 			"prompt":    "magenta",
 			"error":     "red",
 		},
+		Services: []string{"magenta", "blue", "cyan", "green", "yellow", "red"},
 	},
 }
 
@@ -87,6 +94,23 @@ func GetThemeColor(themeName, colorType string) string {
 	return themes["default"].Colors[colorType]
 }
 
+// GetServiceColor は index 番目のサービスに割り当てる色を取得（パレットを循環）
+func GetServiceColor(themeName string, index int) string {
+	palette := themes["default"].Services
+	if theme, exists := themes[themeName]; exists && len(theme.Services) > 0 {
+		palette = theme.Services
+	}
+	if len(palette) == 0 || index < 0 {
+		return ""
+	}
+	return palette[index%len(palette)]
+}
+
+// Colorize は色名（red, cyan など）で文字列に色を付けます
+func Colorize(text, color string) string {
+	return colorizeText(text, color)
+}
+
 // colorizeText は文字列に色を適用
 func colorizeText(text, color string) string {
 	colorCodes := map[string]string{
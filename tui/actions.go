@@ -0,0 +1,258 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// containerAction is an operation the monitor can run on the selected container.
+type containerAction struct {
+	key    string
+	verb   string // shown in the confirmation prompt and the status line
+	args   func(name string) []string
+	danger bool // highlighted in the confirmation prompt
+}
+
+// containerActions are matched on the exact key (so "R" and "r" differ).
+var containerActions = []containerAction{
+	{key: "s", verb: "stop", args: func(n string) []string { return []string{"stop", n} }},
+	{key: "t", verb: "start", args: func(n string) []string { return []string{"start", n} }},
+	{key: "R", verb: "restart", args: func(n string) []string { return []string{"restart", n} }},
+	{key: "K", verb: "kill", args: func(n string) []string { return []string{"kill", n} }, danger: true},
+	{key: "p", verb: "pause/unpause", args: nil}, // resolved against the current state when confirmed
+	{key: "D", verb: "remove (forced)", args: func(n string) []string { return []string{"rm", "-f", n} }, danger: true},
+	{key: "e", verb: "exec shell in", args: nil},
+}
+
+// actionTimeout bounds a single docker call made from the monitor
+const actionTimeout = 30 * time.Second
+
+// pendingAction is an action waiting for y/n confirmation
+type pendingAction struct {
+	action    containerAction
+	container string
+}
+
+// actionDoneMsg reports the result of an action
+type actionDoneMsg struct {
+	verb      string
+	container string
+	output    string
+	err       error
+}
+
+var (
+	statusOK    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	statusErr   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	confirmBold = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	dangerBold  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+)
+
+func findAction(key string) (containerAction, bool) {
+	for _, a := range containerActions {
+		if a.key == key {
+			return a, true
+		}
+	}
+	return containerAction{}, false
+}
+
+// selectedName returns the name of the selected container, or "" when the list is empty
+func (m monitorModel) selectedName() string {
+	rows := m.visible()
+	if m.selected < 0 || m.selected >= len(rows) {
+		return ""
+	}
+	return rows[m.selected].Name
+}
+
+// handleConfirmKey resolves a pending confirmation: y runs the action, anything else cancels it
+func (m monitorModel) handleConfirmKey(key string) (monitorModel, tea.Cmd) {
+	p := *m.confirm
+	m.confirm = nil
+	if key != "y" && key != "Y" {
+		m.status = statusLine{text: "cancelled: " + p.action.verb + " " + p.container, at: time.Now()}
+		return m, nil
+	}
+	m.status = statusLine{text: p.action.verb + " " + p.container + "...", at: time.Now()}
+	switch p.action.key {
+	case "e":
+		return m, execShellCmd(p.container)
+	case "p":
+		return m, togglePauseCmd(p.container)
+	}
+	return m, runDockerActionCmd(p.action.verb, p.container, p.action.args(p.container)...)
+}
+
+// runDockerActionCmd runs docker with args and reports the result as an actionDoneMsg
+func runDockerActionCmd(verb, container string, args ...string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := runDocker(args...)
+		return actionDoneMsg{verb: verb, container: container, output: out, err: err}
+	}
+}
+
+// togglePauseCmd pauses a running container or unpauses a paused one
+func togglePauseCmd(container string) tea.Cmd {
+	return func() tea.Msg {
+		state, err := runDocker("inspect", "-f", "{{.State.Paused}}", container)
+		if err != nil {
+			return actionDoneMsg{verb: "pause", container: container, output: state, err: err}
+		}
+		verb := "pause"
+		if strings.TrimSpace(state) == "true" {
+			verb = "unpause"
+		}
+		out, err := runDocker(verb, container)
+		return actionDoneMsg{verb: verb, container: container, output: out, err: err}
+	}
+}
+
+// execShellCmd suspends the monitor, hands the terminal to an interactive shell in the
+// container and resumes the monitor when the shell exits.
+func execShellCmd(container string) tea.Cmd {
+	// prefer bash, fall back to sh for minimal images
+	cmd := exec.Command("docker", "exec", "-it", container, "sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh")
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return actionDoneMsg{verb: "exec shell in", container: container, err: err}
+	})
+}
+
+// runDocker runs a docker subcommand with a timeout and returns its combined output
+func runDocker(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+	defer cancel()
+	var buf bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &buf
+	cmd.Stderr = &buf
+	err := cmd.Run()
+	return strings.TrimSpace(buf.String()), err
+}
+
+// statusLine is the last action result shown at the bottom of the monitor
+type statusLine struct {
+	text string
+	err  bool
+	at   time.Time
+}
+
+func (s statusLine) render() string {
+	if s.text == "" {
+		return ""
+	}
+	text := s.at.Format("15:04:05") + "  " + s.text
+	if s.err {
+		return statusErr.Render("✖ " + text)
+	}
+	return statusOK.Render("● " + text)
+}
+
+// statusFor builds the status line for a finished action
+func statusFor(msg actionDoneMsg) statusLine {
+	if msg.err != nil {
+		detail := msg.output
+		if i := strings.IndexByte(detail, '\n'); i >= 0 {
+			detail = detail[:i]
+		}
+		if detail == "" {
+			detail = msg.err.Error()
+		}
+		return statusLine{text: fmt.Sprintf("%s %s failed: %s", msg.verb, msg.container, detail), err: true, at: time.Now()}
+	}
+	return statusLine{text: fmt.Sprintf("%s %s: done", msg.verb, msg.container), at: time.Now()}
+}
+
+// render renders the y/n prompt for a pending action
+func (p pendingAction) render() string {
+	question := fmt.Sprintf("%s %s? [y/N]", p.action.verb, p.container)
+	if p.action.danger {
+		return dangerBold.Render("⚠ " + question)
+	}
+	return confirmBold.Render("? " + question)
+}
+
+// logsView shows the last lines of a container's logs on top of the monitor
+type logsView struct {
+	container string
+	lines     []string
+	offset    int // lines scrolled up from the bottom
+	err       error
+}
+
+// logsTail is how many log lines "open logs" fetches
+const logsTail = 500
+
+type logsMsg struct {
+	container string
+	lines     []string
+	err       error
+}
+
+func fetchLogsCmd(container string, tail int) tea.Cmd {
+	return func() tea.Msg {
+		out, err := runDocker("logs", "--tail", fmt.Sprint(tail), container)
+		var lines []string
+		if out != "" {
+			lines = strings.Split(out, "\n")
+		}
+		return logsMsg{container: container, lines: lines, err: err}
+	}
+}
+
+// handleLogsKey scrolls or closes the logs view
+func (m monitorModel) handleLogsKey(key string) (monitorModel, tea.Cmd) {
+	v := m.logs
+	page := max(m.height-4, 5)
+	switch key {
+	case "q", "esc", "l":
+		m.logs = nil
+	case "up", "k":
+		v.offset = min(v.offset+1, max(len(v.lines)-page, 0))
+	case "down", "j":
+		v.offset = max(v.offset-1, 0)
+	case "pgup", "b":
+		v.offset = min(v.offset+page, max(len(v.lines)-page, 0))
+	case "pgdown", "f":
+		v.offset = max(v.offset-page, 0)
+	case "r":
+		return m, fetchLogsCmd(v.container, logsTail)
+	}
+	return m, nil
+}
+
+func (v *logsView) render(width, height int) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Logs: %s  (last %d lines)", v.container, logsTail)))
+	b.WriteString("\n")
+	page := max(height-4, 5)
+	if v.err != nil {
+		b.WriteString(statusErr.Render(v.err.Error()))
+		b.WriteString("\n")
+	}
+	end := len(v.lines) - v.offset
+	start := max(end-page, 0)
+	for _, line := range v.lines[start:end] {
+		if width > 0 {
+			line = truncateRunes(line, width)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n[↑/↓] Scroll  [PgUp/PgDn|b/f] Page  [R] Reload  [L|Esc] Close\n")
+	return b.String()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docsh/internal/alert"
+)
+
+// alertMetaInterval refreshes restart counts and health faster when a rule needs them
+const alertMetaInterval = 5 * time.Second
+
+var alertStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+
+// alertNotifyMsg reports a notification that could not be delivered
+type alertNotifyMsg struct{ err error }
+
+// metaInterval is how often container metadata is refreshed
+func (m monitorModel) metaInterval() time.Duration {
+	if m.opts.Alerts.NeedsState() {
+		return alertMetaInterval
+	}
+	return metaRefreshInterval
+}
+
+// trackState records the restart baseline and when each container turned unhealthy
+func (m *monitorModel) trackState(now time.Time) {
+	for name, meta := range m.meta {
+		if _, ok := m.restartBase[name]; !ok {
+			m.restartBase[name] = meta.RestartCount
+		}
+		if meta.Health != "unhealthy" {
+			delete(m.unhealthySince, name)
+		} else if _, ok := m.unhealthySince[name]; !ok {
+			m.unhealthySince[name] = now
+		}
+	}
+}
+
+// recent returns the samples covering the last d, or nil while there are not enough yet
+func (m monitorModel) recent(h []float64, d time.Duration) []float64 {
+	n := max(int(math.Ceil(float64(d)/float64(m.opts.Interval))), 1)
+	if len(h) < n {
+		return nil
+	}
+	return h[len(h)-n:]
+}
+
+// allAbove reports whether every sample exceeds the threshold
+func allAbove(vals []float64, threshold float64) bool {
+	if len(vals) == 0 {
+		return false
+	}
+	for _, v := range vals {
+		if v <= threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRule evaluates one rule for a container over the history maps
+func (m monitorModel) checkRule(r alert.Rule, s DockStats, now time.Time) (bool, float64) {
+	switch r.Metric {
+	case alert.MetricCPU:
+		return allAbove(m.recent(m.history[s.Name], r.For), r.Above), s.CPU
+	case alert.MetricMem:
+		if s.MemLimit <= 0 {
+			return false, s.MemPerc
+		}
+		var perc []float64
+		for _, v := range m.recent(m.memHist[s.Name], r.For) {
+			perc = append(perc, v/s.MemLimit*100)
+		}
+		return allAbove(perc, r.Above), s.MemPerc
+	case alert.MetricRestarts:
+		meta, ok := m.meta[s.Name]
+		if !ok {
+			return false, 0
+		}
+		restarts := float64(meta.RestartCount - m.restartBase[s.Name])
+		return restarts > r.Above, restarts
+	case alert.MetricHealth:
+		since, ok := m.unhealthySince[s.Name]
+		return ok && now.Sub(since) >= r.For, 0
+	}
+	return false, 0
+}
+
+// evaluateAlerts checks every rule against every listed container and returns the changes
+func (m *monitorModel) evaluateAlerts(now time.Time) []alert.Event {
+	if m.alerts == nil {
+		return nil
+	}
+	var events []alert.Event
+	present := map[string]bool{}
+	for _, s := range m.stats {
+		present[s.Name] = true
+		for i, r := range m.alerts.Rules() {
+			if !r.Matches(s.Name) {
+				continue
+			}
+			holds, value := m.checkRule(r, s, now)
+			if e, changed := m.alerts.Observe(i, s.Name, holds, value, now); changed {
+				events = append(events, e)
+			}
+		}
+	}
+	return append(events, m.alerts.Forget(present, now)...)
+}
+
+// notifyCmd delivers events to the configured bell, log file and hook
+func (m monitorModel) notifyCmd(events []alert.Event) tea.Cmd {
+	// a replay shows past alerts without notifying again
+	if len(events) == 0 || m.replay != nil {
+		return nil
+	}
+	cfg := m.opts.Alerts
+	return func() tea.Msg {
+		for _, e := range events {
+			if err := cfg.Notify(e, os.Stdout); err != nil {
+				return alertNotifyMsg{err}
+			}
+		}
+		return nil
+	}
+}
+
+// alertStatus puts the newest firing alert on the status line
+func (m *monitorModel) alertStatus(events []alert.Event) {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Firing {
+			m.status = statusLine{text: "ALERT " + events[i].Message(), err: true, at: events[i].At}
+			return
+		}
+	}
+}
+
+// alertBanner is the header line listing the firing alerts
+func (m monitorModel) alertBanner(width int) string {
+	if m.alerts == nil {
+		return ""
+	}
+	active := m.alerts.Active()
+	if len(active) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, e := range active {
+		parts = append(parts, e.Container+" "+e.Rule.Describe())
+	}
+	text := fmt.Sprintf("⚠ %d alert(s): %s", len(active), strings.Join(parts, "; "))
+	if width > 0 {
+		text = truncateRunes(text, width)
+	}
+	return alertStyle.Render(text)
+}
+
+// alertTags describes the firing alerts of one container ("" when none)
+func (m monitorModel) alertTags(container string) string {
+	if m.alerts == nil {
+		return ""
+	}
+	var tags []string
+	for _, e := range m.alerts.ActiveFor(container) {
+		tags = append(tags, e.Rule.Describe())
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return "⚠ " + strings.Join(tags, ", ")
+}
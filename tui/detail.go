@@ -0,0 +1,487 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docsh/internal/health"
+)
+
+// detail panes, switched with Tab / ←→ / number keys
+const (
+	paneCharts = iota
+	paneProcesses
+	paneEnv
+	paneMounts
+	panePorts
+	paneHealth
+	paneLogs
+)
+
+var paneTitles = []string{"Charts", "Processes", "Env", "Mounts", "Ports", "Health", "Logs"}
+
+// detailLogLines is how many log lines the Logs pane keeps
+const detailLogLines = 200
+
+// detailView is the drill-down view of one container
+type detailView struct {
+	container string
+	pane      int
+	scroll    int  // lines scrolled in text panes (from the bottom in the Logs pane)
+	areaChart bool // draw filled charts instead of lines
+
+	loading    bool
+	inspect    *health.Container
+	inspectErr error
+	top        []string
+	topErr     error
+	logs       []string
+	logsErr    error
+}
+
+// detailMsg carries everything the detail view fetches for a container
+type detailMsg struct {
+	container  string
+	inspect    *health.Container
+	inspectErr error
+	top        []string
+	topErr     error
+	logs       []string
+	logsErr    error
+}
+
+var (
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1)
+	activeTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Reverse(true)
+	dimStyle       = lipgloss.NewStyle().Faint(true)
+)
+
+// fetchDetailCmd runs docker inspect, top and logs for a container in parallel
+func fetchDetailCmd(container string) tea.Cmd {
+	return func() tea.Msg {
+		msg := detailMsg{container: container}
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			list, err := health.Inspect(container)
+			if err != nil {
+				msg.inspectErr = err
+				return
+			}
+			msg.inspect = &list[0]
+		}()
+		go func() {
+			defer wg.Done()
+			out, err := runDocker("top", container)
+			if err != nil {
+				msg.topErr = fmt.Errorf("%s", firstOutputLine(out, err))
+				return
+			}
+			msg.top = splitLines(out)
+		}()
+		go func() {
+			defer wg.Done()
+			out, err := runDocker("logs", "--tail", fmt.Sprint(detailLogLines), container)
+			if err != nil {
+				msg.logsErr = fmt.Errorf("%s", firstOutputLine(out, err))
+				return
+			}
+			msg.logs = splitLines(out)
+		}()
+		wg.Wait()
+		return msg
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// firstOutputLine prefers docker's own message over the exit status
+func firstOutputLine(out string, err error) string {
+	if i := strings.IndexByte(out, '\n'); i >= 0 {
+		out = out[:i]
+	}
+	if out == "" {
+		return err.Error()
+	}
+	return out
+}
+
+// openDetail switches to the drill-down view of the selected container
+func (m monitorModel) openDetail() (monitorModel, tea.Cmd) {
+	name := m.selectedName()
+	if name == "" {
+		return m, nil
+	}
+	if m.replay != nil {
+		// only the charts were recorded
+		err := errors.New("not recorded (replay)")
+		m.detail = &detailView{container: name, inspectErr: err, topErr: err, logsErr: err}
+		return m, nil
+	}
+	m.detail = &detailView{container: name, loading: true}
+	return m, fetchDetailCmd(name)
+}
+
+func (v *detailView) apply(msg detailMsg) {
+	v.loading = false
+	v.inspect, v.inspectErr = msg.inspect, msg.inspectErr
+	v.top, v.topErr = msg.top, msg.topErr
+	v.logs, v.logsErr = msg.logs, msg.logsErr
+}
+
+// handleDetailKey switches panes, scrolls and leaves the detail view
+func (m monitorModel) handleDetailKey(key string) (monitorModel, tea.Cmd) {
+	v := m.detail
+	switch key {
+	case "esc", "q", "backspace", "enter":
+		m.detail = nil
+	case "tab", "right":
+		v.pane = (v.pane + 1) % len(paneTitles)
+		v.scroll = 0
+	case "shift+tab", "left":
+		v.pane = (v.pane - 1 + len(paneTitles)) % len(paneTitles)
+		v.scroll = 0
+	case "1", "2", "3", "4", "5", "6", "7":
+		v.pane = int(key[0] - '1')
+		v.scroll = 0
+	case "l":
+		v.pane = paneLogs
+		v.scroll = 0
+	case "up", "k", "down", "j", "pgup", "pgdown":
+		step := 1
+		if key == "pgup" || key == "pgdown" {
+			step = max(m.height-6, 5)
+		}
+		if key == "up" || key == "k" || key == "pgup" {
+			step = -step
+		}
+		// the Logs pane counts from the newest line, so scrolling up moves away from the bottom
+		if v.pane == paneLogs {
+			step = -step
+		}
+		lines, _ := v.paneLines()
+		v.scroll = clamp(v.scroll+step, 0, max(len(lines)-max(m.height-6, 1), 0))
+	case "c":
+		v.areaChart = !v.areaChart
+	case "r":
+		if m.replay != nil {
+			return m, nil
+		}
+		v.loading = true
+		return m, fetchDetailCmd(v.container)
+	}
+	return m, nil
+}
+
+// renderDetail draws the header, tab bar and the active pane
+func (m monitorModel) renderDetail() string {
+	v := m.detail
+	width, height := m.width, m.height
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 24
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("🔎 " + v.container))
+	if info := v.inspect; info != nil {
+		b.WriteString("  " + dimStyle.Render(info.Config.Image))
+		b.WriteString("  " + stateSummary(info))
+	}
+	if v.loading {
+		b.WriteString(dimStyle.Render("  loading..."))
+	}
+	b.WriteString("\n")
+	for i, title := range paneTitles {
+		label := fmt.Sprintf("%d %s", i+1, title)
+		if i == v.pane {
+			b.WriteString(activeTabStyle.Render(label))
+		} else {
+			b.WriteString(tabStyle.Render(label))
+		}
+	}
+	b.WriteString("\n\n")
+
+	// header (2 lines + blank), status line and footer
+	body := height - 6
+	if v.pane == paneCharts {
+		b.WriteString(m.renderDetailCharts(width, body))
+	} else {
+		lines, fromBottom := v.paneLines()
+		b.WriteString(scrollWindow(lines, v.scroll, body, width, fromBottom))
+	}
+
+	if m.confirm != nil {
+		b.WriteString("\n" + m.confirm.render() + "\n")
+	} else if line := m.status.render(); line != "" {
+		b.WriteString("\n" + line + "\n")
+	} else {
+		b.WriteString("\n\n")
+	}
+	footer := "[Tab|←/→|1-7] Pane  [↑/↓|PgUp/PgDn] Scroll  [r] Reload  [Esc] Back"
+	if v.pane == paneCharts {
+		_, _, _, cols := detailChartLayout(width, body, 4)
+		footer = "[Tab|←/→|1-7] Pane  [c] Line/Area  [z/Z] Zoom  [r] Reload  [Esc] Back  " + dimStyle.Render(m.zoomLabel(cols))
+	}
+	b.WriteString(footer + "\n")
+	return b.String()
+}
+
+// scrollWindow cuts a page out of lines; fromBottom keeps the newest lines in view
+func scrollWindow(lines []string, scroll, page, width int, fromBottom bool) string {
+	page = max(page, 1)
+	start := scroll
+	if fromBottom {
+		start = len(lines) - page - scroll
+	}
+	start = clamp(start, 0, max(len(lines)-page, 0))
+	end := start + page
+	if end > len(lines) {
+		end = len(lines)
+	}
+	var b strings.Builder
+	for _, line := range lines[start:end] {
+		b.WriteString(truncateRunes(strings.ReplaceAll(line, "\t", "  "), width))
+		b.WriteString("\n")
+	}
+	for i := end - start; i < page; i++ {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// stateSummary is the one-line state shown next to the container name, e.g. "exited(137), OOM killed"
+func stateSummary(info *health.Container) string {
+	st := info.Status()
+	return colorize(st.Summary(), st.Color())
+}
+
+// colorize renders text in one of the color names of health.Status.Color
+func colorize(text, color string) string {
+	if color == "" {
+		return text
+	}
+	return lipgloss.NewStyle().Foreground(eventColors[color]).Render(text)
+}
+
+// paneLines returns the text of a non-chart pane
+func (v *detailView) paneLines() (lines []string, fromBottom bool) {
+	if v.pane == paneProcesses {
+		if v.topErr != nil {
+			return []string{statusErr.Render(v.topErr.Error())}, false
+		}
+		return v.top, false
+	}
+	if v.pane == paneLogs {
+		if v.logsErr != nil {
+			return []string{statusErr.Render(v.logsErr.Error())}, false
+		}
+		return v.logs, true
+	}
+	if v.inspectErr != nil {
+		return []string{statusErr.Render(v.inspectErr.Error())}, false
+	}
+	info := v.inspect
+	if info == nil {
+		return nil, false
+	}
+	switch v.pane {
+	case paneEnv:
+		return info.Config.Env, false
+	case paneMounts:
+		for _, mnt := range info.Mounts {
+			src := mnt.Source
+			if mnt.Type == "volume" && mnt.Name != "" {
+				src = mnt.Name
+			}
+			mode := "ro"
+			if mnt.RW {
+				mode = "rw"
+			}
+			lines = append(lines, fmt.Sprintf("%-7s %s → %s (%s)", mnt.Type, src, mnt.Destination, mode))
+		}
+		if len(lines) == 0 {
+			lines = []string{dimStyle.Render("no mounts")}
+		}
+	case panePorts:
+		ports := make([]string, 0, len(info.NetworkSettings.Ports))
+		for p := range info.NetworkSettings.Ports {
+			ports = append(ports, p)
+		}
+		sort.Strings(ports)
+		for _, p := range ports {
+			bindings := info.NetworkSettings.Ports[p]
+			if len(bindings) == 0 {
+				lines = append(lines, fmt.Sprintf("%-10s (not published)", p))
+				continue
+			}
+			for _, bnd := range bindings {
+				host := bnd.HostIp
+				if host == "" {
+					host = "0.0.0.0"
+				}
+				lines = append(lines, fmt.Sprintf("%-10s → %s:%s", p, host, bnd.HostPort))
+			}
+		}
+		if len(lines) == 0 {
+			lines = []string{dimStyle.Render("no exposed ports")}
+		}
+	case paneHealth:
+		lines = healthLines(info)
+	}
+	return lines, false
+}
+
+// healthLines describes the container state, restart policy and health check log,
+// the same facts `health <container>` prints in the shell
+func healthLines(info *health.Container) []string {
+	st := info.Status()
+	lines := []string{
+		"State:          " + colorize(st.Summary(), st.Color()),
+		"Started:        " + health.FormatTime(info.State.StartedAt),
+	}
+	if st.State != "running" && st.State != "created" {
+		exit := fmt.Sprint(st.ExitCode)
+		if reason := st.ExitReason(); reason != "" {
+			exit += " (" + statusErr.Render(reason) + ")"
+		}
+		lines = append(lines,
+			"Finished:       "+health.FormatTime(info.State.FinishedAt),
+			"Exit code:      "+exit)
+	}
+	if info.State.Error != "" {
+		lines = append(lines, statusErr.Render("Error:          "+info.State.Error))
+	}
+	lines = append(lines,
+		fmt.Sprintf("Restart count:  %d", info.RestartCount),
+		"Restart policy: "+info.RestartPolicy(),
+		"")
+	h := info.State.Health
+	if h == nil {
+		return append(lines, dimStyle.Render("no health check configured"))
+	}
+	lines = append(lines, "Health:         "+colorize(h.Status, health.HealthColor(h.Status)))
+	if check := info.Check(); check != "" {
+		lines = append(lines, "Check:          "+check)
+	}
+	lines = append(lines, fmt.Sprintf("Failing streak: %d", h.FailingStreak), "")
+	// newest probe first
+	for i := len(h.Log) - 1; i >= 0; i-- {
+		probe := h.Log[i]
+		exit := statusOK.Render(fmt.Sprintf("exit %d", probe.ExitCode))
+		if probe.ExitCode != 0 {
+			exit = statusErr.Render(fmt.Sprintf("exit %d", probe.ExitCode))
+		}
+		lines = append(lines, fmt.Sprintf("%s  %8s  %s  %s", health.FormatTime(probe.Start), probe.Took(), exit, probe.OutputLine()))
+	}
+	return lines
+}
+
+// renderDetailCharts lays out the CPU, MEM, NET and DISK charts in a 2x2 grid, or stacked when narrow
+func (m monitorModel) renderDetailCharts(width, height int) string {
+	v := m.detail
+	name := v.container
+	var cur DockStats
+	for _, s := range m.stats {
+		if s.Name == name {
+			cur = s
+		}
+	}
+
+	type chart struct {
+		title string
+		vals  []float64
+		unit  string // chart tick unit
+		sunit string // summary unit
+	}
+	charts := []chart{
+		{cpuColor.Render("CPU") + fmt.Sprintf("  %.1f%%", cur.CPU), m.history[name], "%", "%"},
+		{memColor.Render("MEM") + fmt.Sprintf("  %s (%.1f%%)", cur.MemUsage, cur.MemPerc), m.memHist[name], "B", ""},
+		{netColor.Render("NET") + fmt.Sprintf("  %s/s", humanBytes(lastValue(m.netHist[name]))), m.netHist[name], "B", "/s"},
+		{diskColor.Render("DISK") + fmt.Sprintf(" %s/s", humanBytes(lastValue(m.diskHist[name]))), m.diskHist[name], "B", "/s"},
+	}
+
+	columns, chartRows, colWidth, cols := detailChartLayout(width, height, len(charts))
+	window := m.zoomWindow()
+	if window == 0 {
+		window = time.Duration(cols) * m.opts.Interval
+	}
+
+	render := func(c chart) string {
+		vals := m.zoomed(c.vals, cols)
+		var body string
+		if v.areaChart {
+			body = chartWithAxis(vals, cols, chartRows, c.unit)
+		} else {
+			body = chartLineWithAxis(vals, cols, chartRows, c.unit)
+		}
+		stats := summarize(m.windowValues(c.vals, cols)).format(c.sunit)
+		return c.title + "\n" + dimStyle.Render(truncateRunes(stats, colWidth)) + "\n" + body + timeAxis(cols, window) + "\n"
+	}
+
+	var b strings.Builder
+	if chartRows < minDetailChartRows {
+		// too short for the charts: keep the titles and summaries only
+		lines := []string{dimStyle.Render("enlarge the terminal to show the charts")}
+		for _, c := range charts {
+			stats := summarize(m.windowValues(c.vals, cols)).format(c.sunit)
+			lines = append(lines, c.title, dimStyle.Render(stats))
+		}
+		return scrollWindow(lines, 0, height, width, false)
+	}
+	for i := 0; i < len(charts); i += columns {
+		if columns == 2 {
+			b.WriteString(hstackFixed(render(charts[i]), render(charts[i+1]), colWidth))
+		} else {
+			b.WriteString(render(charts[i]))
+		}
+	}
+	return b.String()
+}
+
+// minDetailChartRows is the shortest chart the detail view draws; below it the charts are hidden
+const minDetailChartRows = 3
+
+// detailChartLayout picks a 2x2 grid when the terminal is wide enough, otherwise stacks
+// the charts. Each chart needs a title line, a summary line, its rows and the time axis;
+// chartRows is what fits in height and may fall below minDetailChartRows.
+func detailChartLayout(width, height, charts int) (columns, chartRows, colWidth, cols int) {
+	const axisW = 9 // tick labels plus the separating space
+	columns, chartRows, colWidth = 1, height/charts-3, width
+	if width >= 2*(axisW+30)+1 {
+		columns, chartRows = 2, height/2-3
+		colWidth = (width - 1) / 2
+	}
+	return columns, chartRows, colWidth, colWidth - axisW - 1
+}
+
+// timeAxis labels the x axis of a chart spanning window
+func timeAxis(cols int, window time.Duration) string {
+	cols = max(cols, 20)
+	left := "-" + formatWindow(window)
+	right := "now"
+	gap := max(cols-len(left)-len(right), 1)
+	return strings.Repeat(" ", 9) + dimStyle.Render(left+strings.Repeat(" ", gap)+right)
+}
+
+func lastValue(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	return vals[len(vals)-1]
+}
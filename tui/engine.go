@@ -0,0 +1,376 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// engineListInterval is how often the engine provider looks for started and stopped containers
+const engineListInterval = 2 * time.Second
+
+// engineEndpoint returns the network and address of the Docker daemon API.
+// Only plain unix sockets and tcp hosts are supported; TLS, named pipes and
+// non-default contexts are left to the docker CLI.
+func engineEndpoint() (network, addr string, err error) {
+	if ctx := os.Getenv("DOCKER_CONTEXT"); ctx != "" && ctx != "default" {
+		return "", "", fmt.Errorf("docker context %q is not supported by the engine stats source", ctx)
+	}
+	if ctx := currentDockerContext(); ctx != "" && ctx != "default" && os.Getenv("DOCKER_HOST") == "" {
+		return "", "", fmt.Errorf("docker context %q is not supported by the engine stats source", ctx)
+	}
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		if runtime.GOOS == "windows" {
+			return "", "", fmt.Errorf("named pipes are not supported by the engine stats source")
+		}
+		host = "unix:///var/run/docker.sock"
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case u.Scheme == "unix":
+		return "unix", u.Path, nil
+	case u.Scheme == "tcp" && os.Getenv("DOCKER_TLS_VERIFY") == "":
+		return "tcp", u.Host, nil
+	}
+	return "", "", fmt.Errorf("DOCKER_HOST %q is not supported by the engine stats source", host)
+}
+
+// currentDockerContext reads currentContext from the docker CLI config
+func currentDockerContext() string {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	json.Unmarshal(data, &cfg)
+	return cfg.CurrentContext
+}
+
+// engineProvider follows the Engine API stats stream of every running container and
+// computes CPU%, memory and I/O from the raw counters, like `docker stats` does.
+type engineProvider struct {
+	client *http.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+	ready  chan struct{} // closed once the first list has samples (or timed out)
+
+	mu      sync.Mutex
+	order   []string // container IDs in the order the daemon lists them
+	latest  map[string]DockStats
+	streams map[string]context.CancelFunc
+	err     error
+}
+
+func newEngineProvider() (*engineProvider, error) {
+	network, addr, err := engineEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &engineProvider{
+		client:  client,
+		ctx:     ctx,
+		cancel:  cancel,
+		ready:   make(chan struct{}),
+		latest:  map[string]DockStats{},
+		streams: map[string]context.CancelFunc{},
+	}
+	ping, pingCancel := context.WithTimeout(ctx, 2*time.Second)
+	defer pingCancel()
+	if err := p.get(ping, "/_ping", nil); err != nil {
+		cancel()
+		return nil, err
+	}
+	go p.run()
+	return p, nil
+}
+
+func (p *engineProvider) Name() string { return StatsSourceEngine }
+
+// get performs an API request and decodes the JSON body into v (when not nil)
+func (p *engineProvider) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker"+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("docker API %s: %s", path, resp.Status)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// run refreshes the container list and keeps one stats stream per running container
+func (p *engineProvider) run() {
+	first := true
+	for {
+		p.refresh()
+		if first {
+			first = false
+			go p.waitFirstSamples()
+		}
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-time.After(engineListInterval):
+		}
+	}
+}
+
+func (p *engineProvider) refresh() {
+	ctx, cancel := context.WithTimeout(p.ctx, 5*time.Second)
+	defer cancel()
+	var list []struct {
+		ID    string `json:"Id"`
+		Names []string
+	}
+	err := p.get(ctx, "/containers/json", &list)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+	if err != nil {
+		return
+	}
+	running := map[string]bool{}
+	p.order = p.order[:0]
+	for _, c := range list {
+		running[c.ID] = true
+		p.order = append(p.order, c.ID)
+		if _, ok := p.streams[c.ID]; ok {
+			continue
+		}
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		sctx, scancel := context.WithCancel(p.ctx)
+		p.streams[c.ID] = scancel
+		go p.follow(sctx, c.ID, name)
+	}
+	for id, stop := range p.streams {
+		if !running[id] {
+			stop()
+			delete(p.streams, id)
+		}
+	}
+	for id := range p.latest {
+		if !running[id] {
+			delete(p.latest, id)
+		}
+	}
+}
+
+// waitFirstSamples holds the first Snapshot until every listed container has reported once
+func (p *engineProvider) waitFirstSamples() {
+	defer close(p.ready)
+	deadline := time.Now().Add(2500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		p.mu.Lock()
+		done := p.err != nil || len(p.latest) >= len(p.order)
+		p.mu.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// follow decodes the stats stream of one container until it stops or is cancelled
+func (p *engineProvider) follow(ctx context.Context, id, name string) {
+	defer func() {
+		p.mu.Lock()
+		// the next refresh reopens the stream if the container is still running
+		if ctx.Err() == nil {
+			delete(p.streams, id)
+		}
+		p.mu.Unlock()
+	}()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://docker/containers/"+id+"/stats?stream=true", nil)
+	if err != nil {
+		return
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var st engineStats
+		if err := dec.Decode(&st); err != nil {
+			return
+		}
+		s := st.toDockStats(id, name)
+		p.mu.Lock()
+		if _, ok := p.streams[id]; ok {
+			p.latest[id] = s
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *engineProvider) Snapshot() ([]DockStats, error) {
+	select {
+	case <-p.ready:
+	case <-time.After(3 * time.Second):
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	rows := make([]DockStats, 0, len(p.order))
+	for _, id := range p.order {
+		if s, ok := p.latest[id]; ok {
+			rows = append(rows, s)
+		}
+	}
+	return rows, nil
+}
+
+func (p *engineProvider) Close() error {
+	p.cancel()
+	return nil
+}
+
+// engineStats is the part of the Engine API stats object the monitor uses (Linux containers)
+type engineStats struct {
+	Read        time.Time `json:"read"`
+	CPUStats    engineCPU `json:"cpu_stats"`
+	PreCPUStats engineCPU `json:"precpu_stats"`
+	MemoryStats struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IOServiceBytesRecursive []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+}
+
+type engineCPU struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// cpuPercent compares the sample with the previous one the daemon embeds in it
+func (st engineStats) cpuPercent() float64 {
+	if st.PreCPUStats.SystemUsage == 0 {
+		return 0 // first sample of the stream: no previous reading yet
+	}
+	cpuDelta := float64(st.CPUStats.CPUUsage.TotalUsage) - float64(st.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(st.CPUStats.SystemUsage) - float64(st.PreCPUStats.SystemUsage)
+	cpus := float64(st.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(st.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// memUsed excludes the page cache, matching `docker stats` on cgroup v1 and v2
+func (st engineStats) memUsed() float64 {
+	usage := st.MemoryStats.Usage
+	cache, ok := st.MemoryStats.Stats["total_inactive_file"]
+	if !ok {
+		cache = st.MemoryStats.Stats["inactive_file"]
+	}
+	if cache < usage {
+		usage -= cache
+	}
+	return float64(usage)
+}
+
+func (st engineStats) toDockStats(id, name string) DockStats {
+	s := DockStats{
+		ID:       id,
+		Name:     name,
+		CPU:      st.cpuPercent(),
+		MemUsed:  st.memUsed(),
+		MemLimit: float64(st.MemoryStats.Limit),
+		Read:     st.Read,
+	}
+	if s.MemLimit > 0 {
+		s.MemPerc = s.MemUsed / s.MemLimit * 100
+	}
+	for _, n := range st.Networks {
+		s.NetRx += float64(n.RxBytes)
+		s.NetTx += float64(n.TxBytes)
+	}
+	for _, e := range st.BlkioStats.IOServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			s.BlockRead += float64(e.Value)
+		case "write":
+			s.BlockWrite += float64(e.Value)
+		}
+	}
+	s.formatUsage()
+	return s
+}
+
+// binaryBytes formats like docker's memory column ("12.3MiB")
+func binaryBytes(v float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f%s", v, units[i])
+	}
+	return fmt.Sprintf("%.4g%s", v, units[i])
+}
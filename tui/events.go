@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"docsh/internal/events"
+)
+
+const (
+	// eventFeedSize is how many events the pane keeps
+	eventFeedSize = 200
+	// eventFeedSince backfills the pane with what happened shortly before it was opened
+	eventFeedSince = "15m"
+	// eventRestartDelay keeps a failing docker events from being restarted on every frame
+	eventRestartDelay = 5 * time.Second
+	// eventPaneWidth is the width of the pane next to the stats
+	eventPaneWidth = 44
+	// eventMinBodyWidth is the narrowest container list the pane goes beside; below it the pane goes under the list
+	eventMinBodyWidth = 64
+	// eventRowsBelow is how many events are shown under the list
+	eventRowsBelow = 5
+)
+
+// eventColors maps the color names of events and health statuses to terminal colors
+var eventColors = map[string]lipgloss.Color{
+	"red": "1", "green": "2", "yellow": "3", "blue": "4", "magenta": "5", "cyan": "6",
+}
+
+// eventFeed follows `docker events` for containers in the background and keeps the latest
+// lifecycle events for the events pane. The monitor restarts it on a tick when the stream ends.
+type eventFeed struct {
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	running bool
+	started time.Time
+	since   string
+	events  []events.Event // oldest first
+	err     error
+}
+
+func newEventFeed() *eventFeed {
+	f := &eventFeed{since: eventFeedSince}
+	f.start()
+	return f
+}
+
+func (f *eventFeed) start() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.running {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	filter := events.Filter{Types: []string{"container"}, Since: f.since}
+	cmd := exec.CommandContext(ctx, "docker", filter.Args()...)
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	f.started = time.Now()
+	if err != nil {
+		cancel()
+		f.err = err
+		return
+	}
+	f.cancel, f.running, f.err = cancel, true, nil
+	go f.read(ctx, cmd, stdout)
+}
+
+func (f *eventFeed) read(ctx context.Context, cmd *exec.Cmd, stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		e, err := events.Parse(scanner.Text())
+		if err != nil || !e.Lifecycle() {
+			continue
+		}
+		f.mu.Lock()
+		f.events = append(f.events, e)
+		if len(f.events) > eventFeedSize {
+			f.events = f.events[len(f.events)-eventFeedSize:]
+		}
+		// a restarted stream only needs what it missed
+		next := e.TimeNano + 1
+		f.since = fmt.Sprintf("%d.%09d", next/1e9, next%1e9)
+		f.mu.Unlock()
+	}
+	err := cmd.Wait()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running = false
+	if ctx.Err() == nil {
+		if err == nil {
+			err = fmt.Errorf("docker events ended")
+		}
+		f.err = err
+	}
+}
+
+// restartIfStopped starts the stream again once it has been down for eventRestartDelay.
+// Update calls it on every tick; View only reads the buffer.
+func (f *eventFeed) restartIfStopped() {
+	f.mu.Lock()
+	restart := !f.running && time.Since(f.started) >= eventRestartDelay
+	f.mu.Unlock()
+	if restart {
+		f.start()
+	}
+}
+
+// latest returns up to n events, newest first, and the error that stopped the stream
+func (f *eventFeed) latest(n int) ([]events.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []events.Event
+	for i := len(f.events) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, f.events[i])
+	}
+	return out, f.err
+}
+
+func (f *eventFeed) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// toggleEvents opens or closes the events pane, starting the feed the first time
+func (m *monitorModel) toggleEvents() {
+	if m.replay != nil {
+		m.status = statusLine{text: "events are not available in replay", err: true, at: time.Now()}
+		return
+	}
+	m.showEvents = !m.showEvents
+	if m.showEvents && m.events == nil {
+		m.events = newEventFeed()
+	}
+}
+
+// eventsBeside reports whether the events pane is open and fits next to the list
+func (m monitorModel) eventsBeside() bool {
+	return m.showEvents && m.width >= eventPaneWidth+1+eventMinBodyWidth
+}
+
+// eventText describes an event for the pane
+func eventText(e events.Event) string {
+	switch e.Verb() {
+	case "die":
+		if code := e.ExitCode(); code != "" {
+			return "exited (" + code + ")"
+		}
+	case "kill":
+		if sig := e.Actor.Attributes["signal"]; sig != "" {
+			return "kill (" + sig + ")"
+		}
+	case "health_status":
+		return "health: " + e.Detail()
+	}
+	return e.Verb()
+}
+
+// renderEventsPane lists up to n of the latest container events under a title, newest first
+func (m monitorModel) renderEventsPane(width, n int) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Events"))
+	b.WriteByte('\n')
+	list, err := m.events.latest(max(n, 1))
+	if err != nil {
+		b.WriteString(alertStyle.Render(truncateRunes("! "+err.Error(), width)))
+		b.WriteByte('\n')
+	}
+	if len(list) == 0 && err == nil {
+		b.WriteString(dimStyle.Render("no events in the last " + eventFeedSince))
+		b.WriteByte('\n')
+	}
+	for _, e := range list {
+		ts := e.Time().Local().Format("15:04:05")
+		what := eventText(e)
+		name := truncateRunes(e.Name(), max(width-runeLen(ts)-runeLen(what)-2, 4))
+		style := lipgloss.NewStyle().Foreground(eventColors[e.Color()])
+		b.WriteString(dimStyle.Render(ts) + " " + name + " " + style.Render(what))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// withEventsPane puts the events pane to the right of the body
+func (m monitorModel) withEventsPane(body string, leftWidth, height int) string {
+	clipped := lipgloss.NewStyle().MaxWidth(leftWidth).Render(strings.TrimRight(body, "\n"))
+	return hstackFixed(clipped, m.renderEventsPane(m.width-leftWidth-1, height-1), leftWidth)
+}
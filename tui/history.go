@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"docsh/internal/alert"
+)
+
+// MonitorOptions configures sampling for the container monitor.
+type MonitorOptions struct {
+	Interval  time.Duration // time between samples
+	Retention time.Duration // how much history is kept per container
+	Source    string        // stats source, see StatsSources
+	Alerts    alert.Config  // threshold rules and notification targets
+	Record    string        // file to record samples to (JSON lines, or CSV by extension)
+	Replay    string        // recording to play back instead of live stats
+	Speed     float64       // replay speed (1 = recorded pace)
+}
+
+// default sampling: one sample per second, ten minutes of history
+const (
+	DefaultMonitorInterval  = time.Second
+	DefaultMonitorRetention = 10 * time.Minute
+)
+
+// withDefaults fills unset or invalid values
+func (o MonitorOptions) withDefaults() MonitorOptions {
+	if o.Interval <= 0 {
+		o.Interval = DefaultMonitorInterval
+	}
+	if o.Retention < o.Interval {
+		o.Retention = DefaultMonitorRetention
+	}
+	if o.Speed <= 0 {
+		o.Speed = 1
+	}
+	return o
+}
+
+// capacity is the number of samples kept per metric
+func (o MonitorOptions) capacity() int {
+	return max(int(o.Retention/o.Interval), 1)
+}
+
+// pushHistory appends v and drops the oldest samples beyond limit
+func pushHistory(h []float64, v float64, limit int) []float64 {
+	h = append(h, v)
+	if len(h) > limit {
+		h = h[len(h)-limit:]
+	}
+	return h
+}
+
+// zoomWindows are the time windows offered by the zoom keys; 0 means one sample per column
+var zoomWindows = []time.Duration{0, time.Minute, 5 * time.Minute, 15 * time.Minute, 30 * time.Minute,
+	time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour}
+
+// zoomLevels returns the windows that fit in the retention, ending with the full retention
+func (o MonitorOptions) zoomLevels() []time.Duration {
+	var levels []time.Duration
+	for _, w := range zoomWindows {
+		if w < o.Retention {
+			levels = append(levels, w)
+		}
+	}
+	return append(levels, o.Retention)
+}
+
+// zoomBy widens the window on "z" and narrows it on "Z"
+func (m *monitorModel) zoomBy(key string) {
+	if key == "z" {
+		m.zoom = min(m.zoom+1, len(m.opts.zoomLevels())-1)
+	} else {
+		m.zoom = max(m.zoom-1, 0)
+	}
+}
+
+// zoomWindow returns the selected window, or 0 for one sample per column
+func (m monitorModel) zoomWindow() time.Duration {
+	levels := m.opts.zoomLevels()
+	return levels[clamp(m.zoom, 0, len(levels)-1)]
+}
+
+// zoomLabel describes the selected window for headers
+func (m monitorModel) zoomLabel(cols int) string {
+	if w := m.zoomWindow(); w > 0 {
+		return "window " + formatWindow(w)
+	}
+	return "window " + formatWindow(time.Duration(cols)*m.opts.Interval) + " (live)"
+}
+
+func formatWindow(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d >= time.Minute && d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
+
+// windowValues returns the samples inside the selected window (no padding)
+func (m monitorModel) windowValues(h []float64, cols int) []float64 {
+	n := cols
+	if w := m.zoomWindow(); w > 0 {
+		n = max(int(w/m.opts.Interval), 1)
+	}
+	if len(h) > n {
+		return h[len(h)-n:]
+	}
+	return h
+}
+
+// zoomed fits the selected window into cols points: the live window takes the last cols
+// samples as-is, wider windows are averaged into one point per column
+func (m monitorModel) zoomed(h []float64, cols int) []float64 {
+	w := m.zoomWindow()
+	if w == 0 {
+		return h
+	}
+	n := max(int(w/m.opts.Interval), 1)
+	seg := make([]float64, n)
+	vals := m.windowValues(h, cols)
+	copy(seg[n-len(vals):], vals)
+	return resample(seg, cols)
+}
+
+// resample maps vals onto n points, averaging buckets when shrinking
+func resample(vals []float64, n int) []float64 {
+	if n <= 0 || len(vals) == 0 {
+		return nil
+	}
+	out := make([]float64, n)
+	for i := range out {
+		lo := i * len(vals) / n
+		hi := (i + 1) * len(vals) / n
+		if hi <= lo {
+			out[i] = vals[lo]
+			continue
+		}
+		out[i] = average(vals[lo:hi])
+	}
+	return out
+}
+
+// summary is min/avg/max/p95 over a window of samples
+type summary struct {
+	Min, Avg, Max, P95 float64
+	N                  int
+}
+
+func summarize(vals []float64) summary {
+	if len(vals) == 0 {
+		return summary{}
+	}
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	p95 := sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+	return summary{Min: sorted[0], Avg: sum / float64(len(sorted)), Max: sorted[len(sorted)-1], P95: p95, N: len(sorted)}
+}
+
+// format renders the summary with the metric's unit ("%" or bytes)
+func (s summary) format(unit string) string {
+	if s.N == 0 {
+		return ""
+	}
+	f := func(v float64) string {
+		if unit == "%" {
+			return fmt.Sprintf("%.1f%%", v)
+		}
+		return humanBytes(v) + unit
+	}
+	return fmt.Sprintf("min %s  avg %s  max %s  p95 %s", f(s.Min), f(s.Avg), f(s.Max), f(s.P95))
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsExporter serves the monitor's stats in the Prometheus text format (metrics serve).
+// Each scrape takes a snapshot from the stats source; image and compose labels come from
+// the same inspect call the monitor uses and are refreshed every metaRefreshInterval.
+type MetricsExporter struct {
+	provider StatsProvider
+
+	mu     sync.Mutex
+	meta   map[string]containerMeta
+	metaAt time.Time
+}
+
+// NewMetricsExporter starts the stats source for the exporter
+func NewMetricsExporter(source string) (*MetricsExporter, error) {
+	provider, err := NewStatsProvider(source)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsExporter{provider: provider, meta: map[string]containerMeta{}}, nil
+}
+
+// Source names the stats source in use
+func (e *MetricsExporter) Source() string { return e.provider.Name() }
+
+func (e *MetricsExporter) Close() error { return e.provider.Close() }
+
+// metricDef is one exported metric family
+type metricDef struct {
+	name  string
+	kind  string // gauge or counter
+	help  string
+	value func(DockStats) float64
+}
+
+var containerMetrics = []metricDef{
+	{"docsh_container_cpu_percent", "gauge", "CPU usage in percent (100 per fully used core).", func(s DockStats) float64 { return s.CPU }},
+	{"docsh_container_memory_usage_bytes", "gauge", "Memory used, excluding the page cache.", func(s DockStats) float64 { return s.MemUsed }},
+	{"docsh_container_memory_limit_bytes", "gauge", "Memory limit of the container.", func(s DockStats) float64 { return s.MemLimit }},
+	{"docsh_container_memory_percent", "gauge", "Memory used in percent of the limit.", func(s DockStats) float64 { return s.MemPerc }},
+	{"docsh_container_network_receive_bytes_total", "counter", "Bytes received on all interfaces.", func(s DockStats) float64 { return s.NetRx }},
+	{"docsh_container_network_transmit_bytes_total", "counter", "Bytes sent on all interfaces.", func(s DockStats) float64 { return s.NetTx }},
+	{"docsh_container_block_read_bytes_total", "counter", "Bytes read from block devices.", func(s DockStats) float64 { return s.BlockRead }},
+	{"docsh_container_block_write_bytes_total", "counter", "Bytes written to block devices.", func(s DockStats) float64 { return s.BlockWrite }},
+}
+
+// ServeHTTP answers /metrics; any other path gets a pointer to it
+func (e *MetricsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/metrics" {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "docsh metrics exporter: scrape /metrics")
+		return
+	}
+	rows, err := e.provider.Snapshot()
+	var meta map[string]containerMeta
+	if err == nil {
+		meta = e.labels(rows)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(e.render(rows, meta, err))
+}
+
+// labels returns the inspect data, refreshing it when it is stale or a container is missing
+func (e *MetricsExporter) labels(rows []DockStats) map[string]containerMeta {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	stale := time.Since(e.metaAt) >= metaRefreshInterval
+	for _, s := range rows {
+		if _, ok := e.meta[s.Name]; !ok {
+			stale = true
+		}
+	}
+	if stale {
+		// a failed inspect keeps the previous labels
+		if msg, ok := fetchMetaCmd()().(metaMsg); ok && msg.err == nil {
+			e.meta = msg.meta
+			e.metaAt = time.Now()
+		}
+	}
+	return e.meta
+}
+
+// render writes every metric family; a failed snapshot still reports docsh_stats_up 0
+func (e *MetricsExporter) render(rows []DockStats, meta map[string]containerMeta, err error) []byte {
+	var b bytes.Buffer
+	up := 1
+	if err != nil {
+		up = 0
+	}
+	fmt.Fprintf(&b, "# HELP docsh_stats_up Whether the last stats snapshot succeeded.\n# TYPE docsh_stats_up gauge\n")
+	fmt.Fprintf(&b, "docsh_stats_up{source=%q} %d\n", e.provider.Name(), up)
+	if err != nil {
+		return b.Bytes()
+	}
+	fmt.Fprintf(&b, "# HELP docsh_containers Number of running containers.\n# TYPE docsh_containers gauge\ndocsh_containers %d\n", len(rows))
+	for _, def := range containerMetrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", def.name, def.help, def.name, def.kind)
+		for _, s := range rows {
+			fmt.Fprintf(&b, "%s{%s} %s\n", def.name, promLabels(s.Name, meta[s.Name]), strconv.FormatFloat(def.value(s), 'g', -1, 64))
+		}
+	}
+	return b.Bytes()
+}
+
+// promLabels renders the label set of one container
+func promLabels(name string, m containerMeta) string {
+	pairs := [][2]string{{"name", name}, {"image", m.Image}, {"compose_project", m.Project}, {"compose_service", m.Service}}
+	parts := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		parts = append(parts, p[0]+`="`+escapeLabel(p[1])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a label value as the text format requires
+func escapeLabel(v string) string { return labelEscaper.Replace(v) }
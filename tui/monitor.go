@@ -11,16 +11,28 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"docsh/internal/alert"
 )
 
-// DockStats holds a single container stat line parsed from `docker stats --no-stream --format`.
+// DockStats holds one container sample from a StatsProvider.
+// The string fields keep docker's display format; the numeric ones are bytes.
 type DockStats struct {
+	ID       string
 	Name     string
 	CPU      float64
 	MemUsage string
 	MemPerc  float64
 	NetIO    string
 	BlockIO  string
+
+	MemUsed    float64
+	MemLimit   float64
+	NetRx      float64 // cumulative
+	NetTx      float64
+	BlockRead  float64 // cumulative
+	BlockWrite float64
+	Read       time.Time // when the sample was taken
 }
 
 // model for Bubble Tea
@@ -29,16 +41,48 @@ type monitorModel struct {
 	paused     bool
 	stats      []DockStats
 	history    map[string][]float64 // cpu history (0..100)
+	memHist    map[string][]float64 // memory usage in bytes
 	netHist    map[string][]float64 // combined up+down bytes/sec
 	diskHist   map[string][]float64 // combined r+w bytes/sec
 	prevTotals map[string]prevTotals
-	maxPoints  int // sparkline width
+	rates      map[string]ioRates // latest per-direction NET/DISK rates
+	maxPoints  int                // sparkline width
 	width      int
 	height     int
 	err        error
 	idx        int
 	selected   int
 	showHelp   bool
+	confirm    *pendingAction // action waiting for y/n
+	status     statusLine     // result of the last action
+	logs       *logsView      // open logs view of one container
+	detail     *detailView    // drill-down view of one container
+
+	compact        bool // one table row per container instead of stacked blocks
+	sortKey        int
+	sortReverse    bool
+	groupByProject bool
+	filter         string
+	filterInput    bool // "/" is being typed
+	meta           map[string]containerMeta
+	metaFetching   bool
+	metaAt         time.Time
+
+	opts     MonitorOptions // sampling interval and retention
+	zoom     int            // index into opts.zoomLevels()
+	provider StatsProvider
+
+	alerts         *alert.Tracker       // nil without alert rules
+	restartBase    map[string]int       // restart count when a container was first seen
+	unhealthySince map[string]time.Time // when a container's health check turned unhealthy
+
+	recorder   *recorder       // open recording, nil when not recording
+	replay     *replayProvider // set when playing back a recording
+	replayDone bool
+	sampleAt   time.Time // time of the last sample (the recorded time in a replay)
+
+	showEvents bool
+	events     *eventFeed // started the first time the events pane is opened
 }
 
 type prevTotals struct {
@@ -49,50 +93,160 @@ type prevTotals struct {
 	ts    time.Time
 }
 
+// ioRates are bytes/sec between the last two samples of a container
+type ioRates struct {
+	up, down    float64
+	read, write float64
+}
+
 type tickMsg time.Time
 type statsMsg struct {
-	rows []DockStats
-	ts   time.Time
+	rows   []DockStats
+	ts     time.Time
+	meta   map[string]containerMeta // recorded metadata (replay only)
+	manual bool                     // fetched outside the tick loop; does not schedule another tick
+}
+
+// replayEndMsg is sent after the last sample of a recording
+type replayEndMsg struct{}
+type errMsg struct {
+	err    error
+	manual bool
 }
-type errMsg struct{ err error }
 
-func newModel() monitorModel {
-	return monitorModel{
+func newModel(opts MonitorOptions, provider StatsProvider) monitorModel {
+	m := monitorModel{
+		opts:       opts.withDefaults(),
+		provider:   provider,
 		loading:    true,
 		paused:     false,
 		stats:      nil,
 		history:    make(map[string][]float64),
+		memHist:    make(map[string][]float64),
 		netHist:    make(map[string][]float64),
 		diskHist:   make(map[string][]float64),
 		prevTotals: make(map[string]prevTotals),
+		rates:      make(map[string]ioRates),
+		meta:       make(map[string]containerMeta),
 		maxPoints:  32,
 		selected:   0,
 		showHelp:   false,
+
+		restartBase:    make(map[string]int),
+		unhealthySince: make(map[string]time.Time),
+	}
+	if len(opts.Alerts.Rules) > 0 {
+		m.alerts = alert.NewTracker(opts.Alerts.Rules)
 	}
+	if rp, ok := provider.(*replayProvider); ok {
+		m.replay = rp
+	}
+	return m
 }
 
 func (m monitorModel) Init() tea.Cmd {
-	return tea.Batch(fetchStatsCmd(), tick())
+	return tea.Batch(refreshStatsCmd(m.provider), tick(m.tickInterval()))
+}
+
+// tickInterval is the sampling interval, shortened by the replay speed
+func (m monitorModel) tickInterval() time.Duration {
+	return time.Duration(float64(m.opts.Interval) / m.opts.Speed)
 }
 
-func tick() tea.Cmd { return tea.Tick(1*time.Second, func(t time.Time) tea.Msg { return tickMsg(t) }) }
+func tick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
 
-func fetchStatsCmd() tea.Cmd {
+func fetchStatsCmd(p StatsProvider) tea.Cmd {
+	if rp, ok := p.(*replayProvider); ok {
+		return func() tea.Msg {
+			rows, meta, ts, err := rp.next()
+			if err != nil {
+				return replayEndMsg{}
+			}
+			return statsMsg{rows: rows, ts: ts, meta: meta}
+		}
+	}
 	return func() tea.Msg {
-		rows, err := fetchOnce()
+		rows, err := p.Snapshot()
 		if err != nil {
-			return errMsg{err}
+			return errMsg{err: err}
 		}
 		return statsMsg{rows: rows, ts: time.Now()}
 	}
 }
 
+// refreshStatsCmd fetches stats once outside the tick loop (startup and the r key),
+// so the result does not start a second chain of ticks
+func refreshStatsCmd(p StatsProvider) tea.Cmd {
+	fetch := fetchStatsCmd(p)
+	return func() tea.Msg {
+		switch msg := fetch().(type) {
+		case statsMsg:
+			msg.manual = true
+			return msg
+		case errMsg:
+			msg.manual = true
+			return msg
+		default:
+			return msg
+		}
+	}
+}
+
 func (m monitorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
 		return m, nil
 	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.confirm != nil {
+			return m.handleConfirmKey(msg.String())
+		}
+		if m.logs != nil {
+			return m.handleLogsKey(strings.ToLower(msg.String()))
+		}
+		if m.filterInput {
+			return m.handleFilterKey(msg)
+		}
+		if a, ok := findAction(msg.String()); ok {
+			if m.replay != nil {
+				m.status = statusLine{text: "container actions are not available in replay", err: true, at: time.Now()}
+				return m, nil
+			}
+			name := m.selectedName()
+			if m.detail != nil {
+				name = m.detail.container
+			}
+			if name != "" {
+				m.confirm = &pendingAction{action: a, container: name}
+			}
+			return m, nil
+		}
+		if msg.String() == "z" || msg.String() == "Z" {
+			m.zoomBy(msg.String())
+			return m, nil
+		}
+		if m.detail != nil {
+			return m.handleDetailKey(msg.String())
+		}
+		if m.replay != nil && (msg.String() == "+" || msg.String() == "=" || msg.String() == "-") {
+			m.changeSpeed(msg.String())
+			return m, nil
+		}
+		if msg.String() == "E" {
+			m.toggleEvents()
+			return m, nil
+		}
+		if msg.String() == "O" {
+			prev := m.selectedName()
+			m.sortReverse = !m.sortReverse
+			m.reselect(prev)
+			return m, nil
+		}
 		switch strings.ToLower(msg.String()) {
 		case "q", "esc", "ctrl+c":
 			return m, tea.Quit
@@ -100,69 +254,115 @@ func (m monitorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.paused = !m.paused
 			return m, nil
 		case "r":
-			return m, fetchStatsCmd()
+			// replay advances with the tick only; a refresh would skip a sample
+			if m.replay != nil {
+				return m, nil
+			}
+			return m, refreshStatsCmd(m.provider)
 		case "h":
 			m.showHelp = !m.showHelp
 			return m, nil
+		case "enter":
+			return m.openDetail()
+		case "l":
+			if m.replay != nil {
+				m.status = statusLine{text: "logs are not available in replay", err: true, at: time.Now()}
+				return m, nil
+			}
+			if name := m.selectedName(); name != "" {
+				return m, fetchLogsCmd(name, logsTail)
+			}
+			return m, nil
+		case "/":
+			m.filterInput = true
+			return m, nil
+		case "w":
+			if m.replay == nil {
+				m.toggleRecording()
+			}
+			return m, nil
+		case "v":
+			m.compact = !m.compact
+			return m, nil
+		case "o":
+			prev := m.selectedName()
+			m.sortKey = (m.sortKey + 1) % len(sortNames)
+			m.reselect(prev)
+			return m, nil
+		case "g":
+			prev := m.selectedName()
+			m.groupByProject = !m.groupByProject
+			m.reselect(prev)
+			return m, nil
 		case "tab", "shift+tab", "down", "j":
-			if len(m.stats) > 0 {
-				m.selected = (m.selected + 1) % len(m.stats)
+			if n := len(m.visible()); n > 0 {
+				m.selected = (m.selected + 1) % n
 			}
 			return m, nil
 		case "up", "k":
-			if len(m.stats) > 0 {
-				m.selected = (m.selected - 1 + len(m.stats)) % len(m.stats)
+			if n := len(m.visible()); n > 0 {
+				m.selected = (m.selected - 1 + n) % n
+			}
+			return m, nil
+		case "pgdown", "pgup":
+			if n := len(m.visible()); n > 0 {
+				step := m.pageSize()
+				if msg.String() == "pgup" {
+					step = -step
+				}
+				m.selected = clamp(m.selected+step, 0, n-1)
 			}
 			return m, nil
 		}
 	case tickMsg:
+		if m.events != nil && m.showEvents {
+			m.events.restartIfStopped()
+		}
 		if !m.paused {
-			return m, fetchStatsCmd()
+			return m, fetchStatsCmd(m.provider)
 		}
-		return m, tick()
+		return m, tick(m.tickInterval())
 	case statsMsg:
-		m.loading = false
-		m.stats = msg.rows
-		// update history
-		for _, s := range m.stats {
-			h := m.history[s.Name]
-			h = append(h, s.CPU)
-			if len(h) > 30 { // keep last 30 points
-				h = h[len(h)-30:]
-			}
-			m.history[s.Name] = h
-			// NET/DISK instantaneous rates from cumulative totals
-			rx, tx := parseTwoBytes(s.NetIO)
-			br, bw := parseTwoBytes(s.BlockIO)
-			prev := m.prevTotals[s.Name]
-			if !prev.ts.IsZero() {
-				dt := msg.ts.Sub(prev.ts).Seconds()
-				if dt > 0 {
-					upRate := maxFloat((tx-prev.netTx)/dt, 0)
-					downRate := maxFloat((rx-prev.netRx)/dt, 0)
-					nh := m.netHist[s.Name]
-					nh = append(nh, upRate+downRate)
-					if len(nh) > m.maxPoints {
-						nh = nh[len(nh)-m.maxPoints:]
-					}
-					m.netHist[s.Name] = nh
-
-					rRate := maxFloat((br-prev.blkR)/dt, 0)
-					wRate := maxFloat((bw-prev.blkW)/dt, 0)
-					dh := m.diskHist[s.Name]
-					dh = append(dh, rRate+wRate)
-					if len(dh) > m.maxPoints {
-						dh = dh[len(dh)-m.maxPoints:]
-					}
-					m.diskHist[s.Name] = dh
-				}
-			}
-			m.prevTotals[s.Name] = prevTotals{netRx: rx, netTx: tx, blkR: br, blkW: bw, ts: msg.ts}
+		events := m.applyStats(msg)
+		m.record(msg.ts)
+		cmds := []tea.Cmd{m.notifyCmd(events)}
+		if !msg.manual {
+			cmds = append(cmds, tick(m.tickInterval()))
 		}
-		return m, tick()
+		if m.needsMeta() {
+			m.metaFetching = true
+			cmds = append(cmds, fetchMetaCmd())
+		}
+		return m, tea.Batch(cmds...)
+	case replayEndMsg:
+		m.replayDone = true
+		m.status = statusLine{text: "end of recording", at: time.Now()}
+		return m, nil
 	case errMsg:
 		m.err = msg.err
-		return m, tick()
+		if msg.manual {
+			return m, nil
+		}
+		return m, tick(m.tickInterval())
+	case actionDoneMsg:
+		m.status = statusFor(msg)
+		if m.detail != nil {
+			return m, fetchDetailCmd(m.detail.container)
+		}
+		return m, nil
+	case metaMsg:
+		return m, m.notifyCmd(m.applyMeta(msg))
+	case alertNotifyMsg:
+		m.status = statusLine{text: "alert notification failed: " + msg.err.Error(), err: true, at: time.Now()}
+		return m, nil
+	case detailMsg:
+		if m.detail != nil && m.detail.container == msg.container {
+			m.detail.apply(msg)
+		}
+		return m, nil
+	case logsMsg:
+		m.logs = &logsView{container: msg.container, lines: msg.lines, err: msg.err}
+		return m, nil
 	}
 	return m, nil
 }
@@ -188,16 +388,49 @@ func (m monitorModel) View() string {
 	if m.loading {
 		return "Loading docker stats... (q to quit)\n"
 	}
+	if m.logs != nil {
+		return m.logs.render(m.width, m.height)
+	}
+	if m.detail != nil {
+		return m.renderDetail()
+	}
 	var b strings.Builder
+	rows := m.visible()
 	// Header with selection info and time
+	clock := time.Now().Format("15:04:05")
+	if m.replay != nil {
+		clock = m.sampleAt.Format("2006-01-02 15:04:05") + "  " + m.replayLabel()
+	}
 	header := titleStyle.Render(fmt.Sprintf("Container Monitor   [%d/%d]  %s",
-		clamp(m.selected+1, 1, len(m.stats)), len(m.stats), time.Now().Format("15:04:05")))
+		clamp(m.selected+1, 1, len(rows)), len(rows), clock))
 	b.WriteString(header)
+	b.WriteString(dimStyle.Render(m.viewSettings()))
 	b.WriteString("\n")
-	{
-		for i, s := range m.stats {
-			if i > 0 {
-				b.WriteString("\n")
+	if banner := m.alertBanner(m.width); banner != "" {
+		b.WriteString(banner + "\n")
+	}
+	if m.filterInput {
+		b.WriteString(confirmBold.Render("/"+m.filter) + "█\n")
+	}
+	// the events pane sits next to the containers, or under them on narrow terminals
+	var body strings.Builder
+	bodyWidth := m.width
+	if m.eventsBeside() {
+		bodyWidth = m.width - eventPaneWidth - 1
+	}
+	page := ""
+	if m.compact || m.groupByProject {
+		var table string
+		table, page = m.renderTable(bodyWidth, m.bodyRows())
+		body.WriteString(table)
+	} else {
+		perPage := m.pageSize()
+		start, p, pages := pageOf(m.selected, len(rows), perPage)
+		page = pageLabel(p, pages)
+		for i := start; i < min(start+perPage, len(rows)); i++ {
+			s := rows[i]
+			if i > start {
+				body.WriteString("\n")
 			}
 			name := "🐳   " + s.Name
 			if i == m.selected {
@@ -206,72 +439,222 @@ func (m monitorModel) View() string {
 				name = nameStyle.Render(name)
 			}
 			header := fmt.Sprintf("%s", name)
-			b.WriteString(header)
-			b.WriteString("\n")
+			if _, badge := healthBadge(m.meta[s.Name]); badge != "" {
+				header += "  " + badge
+			}
+			if tags := m.alertTags(s.Name); tags != "" {
+				header += "  " + alertStyle.Render(tags)
+			}
+			body.WriteString(header)
+			body.WriteString("\n")
 
 			// CPU bar
-			b.WriteString(cpuColor.Render("CPU:"))
-			b.WriteString("  ")
-			b.WriteString(progressBar(s.CPU, 10))
-			avg := average(m.history[s.Name])
-			b.WriteString(fmt.Sprintf(" %2.0f%%  ", s.CPU))
-			b.WriteString(sparkline(m.history[s.Name], m.maxPoints))
+			body.WriteString(cpuColor.Render("CPU:"))
+			body.WriteString("  ")
+			body.WriteString(progressBar(s.CPU, 10))
+			avg := average(m.windowValues(m.history[s.Name], m.maxPoints))
+			body.WriteString(fmt.Sprintf(" %2.0f%%  ", s.CPU))
+			body.WriteString(sparkline(m.zoomed(m.history[s.Name], m.maxPoints), m.maxPoints))
 			if avg >= 0 {
-				b.WriteString(fmt.Sprintf(" (avg: %2.0f%%)", avg))
+				body.WriteString(fmt.Sprintf(" (avg: %2.0f%%)", avg))
 			}
-			b.WriteString("\n")
+			body.WriteString("\n")
 
 			// MEM bar (only percentage, plus raw usage as-is)
-			b.WriteString(memColor.Render("MEM:"))
-			b.WriteString("  ")
-			b.WriteString(progressBar(s.MemPerc, 10))
-			b.WriteString(fmt.Sprintf(" %2.0f%%  %s\n", s.MemPerc, s.MemUsage))
-
-			// NET instantaneous rate (from prev totals) + sparkline
-			rx, tx := parseTwoBytes(s.NetIO)
-			prev := m.prevTotals[s.Name]
-			var upRate, downRate float64
-			if !prev.ts.IsZero() {
-				dt := time.Since(prev.ts).Seconds()
-				if dt > 0 {
-					upRate = maxFloat((tx-prev.netTx)/dt, 0)
-					downRate = maxFloat((rx-prev.netRx)/dt, 0)
-				}
-			}
-			b.WriteString(netColor.Render("NET:"))
-			b.WriteString("  ")
-			b.WriteString(fmt.Sprintf("↑%s/s ↓%s/s  ", humanBytes(upRate), humanBytes(downRate)))
-			b.WriteString(sparkline(m.netHist[s.Name], m.maxPoints))
-			b.WriteString("\n")
-			// DISK instantaneous rate + sparkline
-			br, bw := parseTwoBytes(s.BlockIO)
-			var rRate, wRate float64
-			if !prev.ts.IsZero() {
-				dt := time.Since(prev.ts).Seconds()
-				if dt > 0 {
-					rRate = maxFloat((br-prev.blkR)/dt, 0)
-					wRate = maxFloat((bw-prev.blkW)/dt, 0)
-				}
-			}
-			b.WriteString(diskColor.Render("DISK:"))
-			b.WriteString(" ")
-			b.WriteString(fmt.Sprintf("R:%s/s W:%s/s  ", humanBytes(rRate), humanBytes(wRate)))
-			b.WriteString(sparkline(m.diskHist[s.Name], m.maxPoints))
-			b.WriteString("\n")
+			body.WriteString(memColor.Render("MEM:"))
+			body.WriteString("  ")
+			body.WriteString(progressBar(s.MemPerc, 10))
+			body.WriteString(fmt.Sprintf(" %2.0f%%  ", s.MemPerc))
+			body.WriteString(sparkline(m.zoomed(m.memHist[s.Name], m.maxPoints), m.maxPoints))
+			body.WriteString(fmt.Sprintf(" %s\n", s.MemUsage))
+
+			// NET rate between the last two samples + sparkline
+			r := m.rates[s.Name]
+			body.WriteString(netColor.Render("NET:"))
+			body.WriteString("  ")
+			body.WriteString(fmt.Sprintf("↑%s/s ↓%s/s  ", humanBytes(r.up), humanBytes(r.down)))
+			body.WriteString(sparkline(m.zoomed(m.netHist[s.Name], m.maxPoints), m.maxPoints))
+			body.WriteString("\n")
+			// DISK rate + sparkline
+			body.WriteString(diskColor.Render("DISK:"))
+			body.WriteString(" ")
+			body.WriteString(fmt.Sprintf("R:%s/s W:%s/s  ", humanBytes(r.read), humanBytes(r.write)))
+			body.WriteString(sparkline(m.zoomed(m.diskHist[s.Name], m.maxPoints), m.maxPoints))
+			body.WriteString("\n")
 		}
 	}
+	switch {
+	case m.eventsBeside():
+		b.WriteString(m.withEventsPane(body.String(), bodyWidth, m.bodyRows()))
+	case m.showEvents:
+		b.WriteString(body.String())
+		b.WriteString("\n" + m.renderEventsPane(m.width, eventRowsBelow))
+	default:
+		b.WriteString(body.String())
+	}
+	if m.confirm != nil {
+		b.WriteString("\n")
+		b.WriteString(m.confirm.render())
+		b.WriteString("\n")
+	} else if line := m.status.render(); line != "" {
+		b.WriteString("\n")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
 	if m.showHelp {
 		b.WriteString("\n")
-		help := boxStyle.Render("[↑/↓|TAB] Switch   [SPACE] Pause   [R] Refresh   [H] Help   [Q] Quit\n" +
+		help := boxStyle.Render("[↑/↓|TAB] Switch   [SPACE] Pause   [r] Refresh   [H] Help   [Q] Quit\n" +
+			"[s] Stop  [t] Start  [Shift+R] Restart  [Shift+K] Kill  [p] Pause/Unpause  [Shift+D] Remove\n" +
+			"[e] Exec shell  [l] Logs   (y で実行、それ以外のキーで取り消し)\n" +
+			"[Enter] Detail: グラフ・プロセス・環境変数・マウント・ポート・ヘルス・ログをタブで切り替え\n" +
+			"[z/Z] Zoom out/in (sparklines and detail charts)\n" +
+			"[w] Record samples to a file (JSON lines, CSV with --record x.csv)   [+/-] Replay speed\n" +
+			"[Shift+E] Events pane: latest container starts, stops, restarts, OOM kills and health changes\n" +
+			"[v] Table  [o] Sort (cpu/mem/net/disk/name)  [Shift+O] Reverse  [g] Group by project  [/] Filter (name:, image:, label:)\n" +
 			"- CPU/MEM: バーは使用率、CPUは右に履歴スパークラインと平均\n" +
 			"- NET/DISK: 瞬間転送量を算出し、右側にスパークライン表示")
 		b.WriteString(help)
 	} else {
-		b.WriteString("\n[↑/↓|TAB] Switch  [SPACE] Pause  [R] Refresh  [H] Help  [Q] Quit\n")
+		if m.replay != nil {
+			b.WriteString("\n[↑/↓|TAB] Switch  [SPACE] Pause  [+/-] Speed  [Enter] Detail  [v/o/g|/] View  [z/Z] Zoom  [H] Help  [Q] Quit")
+		} else {
+			b.WriteString("\n[↑/↓|TAB] Switch  [SPACE] Pause  [r] Refresh  [Enter] Detail  [s/t/R/K/p/D/e/l] Actions  [v/o/g|/] View  [E] Events  [z/Z] Zoom  [w] Record  [H] Help  [Q] Quit")
+		}
+		if page != "" {
+			b.WriteString("  " + dimStyle.Render(page))
+		}
+		b.WriteString("\n")
 	}
 	return b.String()
 }
 
+// applyStats stores a sample, extends the history and returns alert changes
+func (m *monitorModel) applyStats(msg statsMsg) []alert.Event {
+	m.loading = false
+	m.err = nil
+	sel := m.selectedName()
+	m.stats = msg.rows
+	m.sampleAt = msg.ts
+	if msg.meta != nil {
+		m.meta, m.metaAt = msg.meta, msg.ts
+	}
+	// update history (retention / interval samples per metric)
+	limit := m.opts.capacity()
+	for _, s := range m.stats {
+		// NET/DISK rates from cumulative totals, timed by the sample itself when known
+		ts := s.Read
+		if ts.IsZero() {
+			ts = msg.ts
+		}
+		prev := m.prevTotals[s.Name]
+		var r ioRates // zero for the first sample, so every series starts together
+		if !prev.ts.IsZero() {
+			dt := ts.Sub(prev.ts).Seconds()
+			if dt <= 0 {
+				// the provider has no newer sample yet; skip it in every series so the
+				// histories keep the same length and the charts' time axes line up
+				continue
+			}
+			r = ioRates{
+				up:    maxFloat((s.NetTx-prev.netTx)/dt, 0),
+				down:  maxFloat((s.NetRx-prev.netRx)/dt, 0),
+				read:  maxFloat((s.BlockRead-prev.blkR)/dt, 0),
+				write: maxFloat((s.BlockWrite-prev.blkW)/dt, 0),
+			}
+		}
+		m.rates[s.Name] = r
+		m.history[s.Name] = pushHistory(m.history[s.Name], s.CPU, limit)
+		m.memHist[s.Name] = pushHistory(m.memHist[s.Name], s.MemUsed, limit)
+		m.netHist[s.Name] = pushHistory(m.netHist[s.Name], r.up+r.down, limit)
+		m.diskHist[s.Name] = pushHistory(m.diskHist[s.Name], r.read+r.write, limit)
+		m.prevTotals[s.Name] = prevTotals{netRx: s.NetRx, netTx: s.NetTx, blkR: s.BlockRead, blkW: s.BlockWrite, ts: ts}
+	}
+	// keep the selection on the same container after re-sorting (or a removal)
+	m.reselect(sel)
+	events := m.evaluateAlerts(msg.ts)
+	m.alertStatus(events)
+	return events
+}
+
+// applyMeta stores inspect metadata and returns alert changes from restarts and health
+func (m *monitorModel) applyMeta(msg metaMsg) []alert.Event {
+	m.metaFetching = false
+	m.metaAt = time.Now()
+	if msg.err != nil {
+		return nil
+	}
+	sel := m.selectedName()
+	m.meta = msg.meta
+	m.reselect(sel)
+	m.trackState(m.metaAt)
+	events := m.evaluateAlerts(m.metaAt)
+	m.alertStatus(events)
+	return events
+}
+
+// viewSettings summarizes the stats source and the active sort, filter and grouping for the header
+func (m monitorModel) viewSettings() string {
+	var parts []string
+	if m.provider != nil && m.replay == nil {
+		parts = append(parts, "source: "+m.provider.Name())
+	}
+	if m.recorder != nil {
+		parts = append(parts, fmt.Sprintf("● REC %s (%d)", m.recorder.path, m.recorder.samples))
+	}
+	if m.sortKey != sortDocker {
+		dir := "↓"
+		if m.sortReverse == (m.sortKey != sortName) {
+			dir = "↑"
+		}
+		parts = append(parts, "sort: "+sortNames[m.sortKey]+dir)
+	}
+	if m.filter != "" && !m.filterInput {
+		parts = append(parts, fmt.Sprintf("filter: %s (%d of %d)", m.filter, len(m.visible()), len(m.stats)))
+	}
+	if m.groupByProject {
+		parts = append(parts, "by project")
+	}
+	if m.zoom > 0 {
+		parts = append(parts, m.zoomLabel(m.maxPoints))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "   " + strings.Join(parts, "  ")
+}
+
+// bodyRows is the number of terminal lines left for the container list
+func (m monitorModel) bodyRows() int {
+	height := m.height
+	if height <= 0 {
+		height = 24
+	}
+	// header, status line, footer and the blank lines around them
+	reserved := 5
+	if m.filterInput {
+		reserved++
+	}
+	if m.alertBanner(0) != "" {
+		reserved++
+	}
+	if m.showEvents && !m.eventsBeside() {
+		reserved += eventRowsBelow + 1
+	}
+	if m.showHelp {
+		reserved += 11
+	}
+	return max(height-reserved, 3)
+}
+
+// pageSize is how many containers fit on one page in the current mode
+func (m monitorModel) pageSize() int {
+	if m.compact || m.groupByProject {
+		return max(m.bodyRows()-1, 1)
+	}
+	// five lines per container plus a blank separator
+	return max((m.bodyRows()+1)/6, 1)
+}
+
 func progressBar(perc float64, cells int) string {
 	if perc < 0 {
 		perc = 0
@@ -353,25 +736,8 @@ func bigChart(vals []float64, width, height int) string {
 		width = 48
 	}
 	// prepare segment similar to sparkline
-	var seg []float64
-	if len(vals) > width {
-		seg = vals[len(vals)-width:]
-	} else {
-		seg = make([]float64, width)
-		copy(seg[width-len(vals):], vals)
-	}
-	minV, maxV := math.MaxFloat64, -math.MaxFloat64
-	for _, v := range seg {
-		if v < minV {
-			minV = v
-		}
-		if v > maxV {
-			maxV = v
-		}
-	}
-	if !isFinite(minV) || !isFinite(maxV) || maxV-minV < 1e-9 {
-		minV, maxV = 0, 1
-	}
+	seg := chartWindow(vals, width)
+	minV, maxV := chartRange(seg)
 	// draw from top row to bottom
 	var b strings.Builder
 	for row := height - 1; row >= 0; row-- {
@@ -391,29 +757,20 @@ func bigChart(vals []float64, width, height int) string {
 
 func isFinite(f float64) bool { return !(math.IsNaN(f) || math.IsInf(f, 0)) }
 
-// chartWithAxis left for compatibility (not used in List mode now)
-func chartWithAxis(vals []float64, width, height int, unit string) string { return "" }
-
-// chartLineWithAxis renders a smooth-looking line using Unicode braille characters.
-// Each cell encodes a 2x4 dot matrix to achieve sub-row resolution (similarにLazydockerの滑らかさを再現)。
-func chartLineWithAxis(vals []float64, width, height int, unit string) string {
-	if width < 20 {
-		width = 20
-	}
-	if height < 6 {
-		height = 6
-	}
-
-	// segment
-	seg := vals
-	if len(seg) > width {
-		seg = seg[len(seg)-width:]
+// chartWindow returns the last width values, left-padded with zeros. It is a copy so
+// smoothing never writes back into the history.
+func chartWindow(vals []float64, width int) []float64 {
+	seg := make([]float64, width)
+	if len(vals) > width {
+		copy(seg, vals[len(vals)-width:])
 	} else {
-		tmp := make([]float64, width)
-		copy(tmp[width-len(seg):], seg)
-		seg = tmp
+		copy(seg[width-len(vals):], vals)
 	}
-	// scale
+	return seg
+}
+
+// chartRange returns the min and max of seg, falling back to 0..1 for flat or empty data
+func chartRange(seg []float64) (float64, float64) {
 	minV, maxV := math.MaxFloat64, -math.MaxFloat64
 	for _, v := range seg {
 		if v < minV {
@@ -426,6 +783,53 @@ func chartLineWithAxis(vals []float64, width, height int, unit string) string {
 	if !isFinite(minV) || !isFinite(maxV) || maxV-minV < 1e-9 {
 		minV, maxV = 0, 1
 	}
+	return minV, maxV
+}
+
+// chartWithAxis renders bigChart's filled columns with y-axis ticks on the left
+func chartWithAxis(vals []float64, width, height int, unit string) string {
+	if width < 20 {
+		width = 20
+	}
+	if height < minDetailChartRows {
+		height = minDetailChartRows
+	}
+	minV, maxV := chartRange(chartWindow(vals, width))
+	rows := strings.Split(strings.TrimRight(bigChart(vals, width, height), "\n"), "\n")
+	tickW := 8
+	var b strings.Builder
+	for r, row := range rows {
+		label := ""
+		switch r {
+		case 0:
+			label = formatTick(maxV, unit)
+		case len(rows) / 2:
+			label = formatTick((maxV+minV)/2, unit)
+		case len(rows) - 1:
+			label = formatTick(minV, unit)
+		}
+		b.WriteString(padLeft(label, tickW))
+		b.WriteString(" ")
+		b.WriteString(row)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// chartLineWithAxis renders a smooth-looking line using Unicode braille characters.
+// Each cell encodes a 2x4 dot matrix to achieve sub-row resolution (similarにLazydockerの滑らかさを再現)。
+func chartLineWithAxis(vals []float64, width, height int, unit string) string {
+	if width < 20 {
+		width = 20
+	}
+	if height < minDetailChartRows {
+		height = minDetailChartRows
+	}
+
+	// segment
+	seg := chartWindow(vals, width)
+	// scale
+	minV, maxV := chartRange(seg)
 	// add padding to avoid jitter when values are flat
 	rng := maxV - minV
 	pad := rng * 0.1
@@ -602,8 +1006,9 @@ func hstackFixed(left, right string, leftWidth int) string {
 		} else {
 			r = ""
 		}
-		if runeLen(l) < leftWidth {
-			l += strings.Repeat(" ", leftWidth-runeLen(l))
+		// lipgloss.Width ignores the escape codes of styled text
+		if w := lipgloss.Width(l); w < leftWidth {
+			l += strings.Repeat(" ", leftWidth-w)
 		} else if runeLen(l) > leftWidth {
 			l = truncateRunes(l, leftWidth)
 		}
@@ -653,16 +1058,7 @@ func fetchOnce() ([]DockStats, error) {
 		if len(cols) < 6 {
 			continue
 		}
-		cpu := parsePercent(cols[1])
-		memp := parsePercent(cols[3])
-		rows = append(rows, DockStats{
-			Name:     cols[0],
-			CPU:      cpu,
-			MemUsage: cols[2],
-			MemPerc:  memp,
-			NetIO:    cols[4],
-			BlockIO:  cols[5],
-		})
+		rows = append(rows, newCLIStats(cols[0], cols[1], cols[2], cols[3], cols[4], cols[5]))
 	}
 	return rows, nil
 }
@@ -780,11 +1176,43 @@ func clamp(v, lo, hi int) int {
 }
 
 // NewMonitorProgram returns a function to run the Bubble Tea program.
-func NewMonitorProgram() func() error {
+func NewMonitorProgram(opts MonitorOptions) func() error {
 	return func() error {
-		p := tea.NewProgram(newModel(), tea.WithAltScreen())
+		var provider StatsProvider
+		if opts.Replay != "" {
+			rp, err := newReplayProvider(opts.Replay)
+			if err != nil {
+				return err
+			}
+			// history steps follow the recording, not the live interval
+			opts.Interval = rp.interval()
+			provider = rp
+		} else {
+			sp, err := NewStatsProvider(opts.Source)
+			if err != nil {
+				return err
+			}
+			provider = sp
+		}
+		defer provider.Close()
+		m := newModel(opts, provider)
+		if opts.Record != "" && opts.Replay == "" {
+			r, err := startRecorder(opts.Record, false)
+			if err != nil {
+				return err
+			}
+			m.recorder = r
+		}
+		p := tea.NewProgram(m, tea.WithAltScreen())
 		// AltScreenから戻る際にカーソルを隠すコマンドを返す
-		_, err := p.Run()
+		final, err := p.Run()
+		// close the recording the UI left open
+		if fm, ok := final.(monitorModel); ok && fm.recorder != nil {
+			fm.recorder.close()
+		}
+		if fm, ok := final.(monitorModel); ok && fm.events != nil {
+			fm.events.close()
+		}
 		// 戻る直前にカーソルが可視化されることがあるので明示的に非表示
 		fmt.Print("\x1b[?25l")
 		return err
@@ -0,0 +1,336 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recordSample is one line of a JSON-lines recording: every container at one point in time
+type recordSample struct {
+	Time       time.Time         `json:"time"`
+	Containers []recordContainer `json:"containers"`
+}
+
+type recordContainer struct {
+	Name       string  `json:"name"`
+	Image      string  `json:"image,omitempty"`
+	Project    string  `json:"project,omitempty"`
+	Service    string  `json:"service,omitempty"`
+	CPU        float64 `json:"cpu"`
+	MemPerc    float64 `json:"mem_perc"`
+	MemUsed    float64 `json:"mem_used"`
+	MemLimit   float64 `json:"mem_limit"`
+	NetRx      float64 `json:"net_rx"`
+	NetTx      float64 `json:"net_tx"`
+	BlockRead  float64 `json:"block_read"`
+	BlockWrite float64 `json:"block_write"`
+}
+
+// csvHeader is the first row of a CSV recording; every other row is one container in one sample
+var csvHeader = []string{"time", "name", "image", "project", "service", "cpu", "mem_perc", "mem_used", "mem_limit",
+	"net_rx", "net_tx", "block_read", "block_write"}
+
+// isCSV picks the recording format from the file extension (JSON lines otherwise)
+func isCSV(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// defaultRecordPath names a recording started with the key toggle
+func defaultRecordPath(now time.Time) string {
+	return "docsh-htop-" + now.Format("20060102-150405") + ".jsonl"
+}
+
+// recorder appends every stats sample to a file
+type recorder struct {
+	path    string
+	f       *os.File
+	csv     *csv.Writer
+	samples int
+}
+
+// startRecorder opens a recording. With resume it appends to an existing file and
+// writes the CSV header only when the file is still empty; otherwise the file is truncated.
+func startRecorder(path string, resume bool) (*recorder, error) {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if resume {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r := &recorder{path: path, f: f}
+	if isCSV(path) {
+		r.csv = csv.NewWriter(f)
+		if info.Size() > 0 {
+			return r, nil
+		}
+		if err := r.csv.Write(csvHeader); err != nil {
+			f.Close()
+			return nil, err
+		}
+		r.csv.Flush()
+	}
+	return r, nil
+}
+
+// write stores one sample; the file is flushed every time so a crash keeps what was recorded
+func (r *recorder) write(ts time.Time, rows []DockStats, meta map[string]containerMeta) error {
+	sample := recordSample{Time: ts}
+	for _, s := range rows {
+		m := meta[s.Name]
+		sample.Containers = append(sample.Containers, recordContainer{
+			Name: s.Name, Image: m.Image, Project: m.Project, Service: m.Service,
+			CPU: s.CPU, MemPerc: s.MemPerc, MemUsed: s.MemUsed, MemLimit: s.MemLimit,
+			NetRx: s.NetRx, NetTx: s.NetTx, BlockRead: s.BlockRead, BlockWrite: s.BlockWrite,
+		})
+	}
+	r.samples++
+	if r.csv == nil {
+		data, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		_, err = r.f.Write(append(data, '\n'))
+		return err
+	}
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, c := range sample.Containers {
+		r.csv.Write([]string{ts.Format(time.RFC3339Nano), c.Name, c.Image, c.Project, c.Service,
+			f(c.CPU), f(c.MemPerc), f(c.MemUsed), f(c.MemLimit), f(c.NetRx), f(c.NetTx), f(c.BlockRead), f(c.BlockWrite)})
+	}
+	r.csv.Flush()
+	return r.csv.Error()
+}
+
+func (r *recorder) close() error {
+	return r.f.Close()
+}
+
+// loadRecording reads a JSON-lines or CSV recording
+func loadRecording(path string) ([]recordSample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var samples []recordSample
+	if isCSV(path) {
+		samples, err = readCSVRecording(f)
+	} else {
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		line := 0
+		for sc.Scan() {
+			line++
+			if strings.TrimSpace(sc.Text()) == "" {
+				continue
+			}
+			var s recordSample
+			if err := json.Unmarshal(sc.Bytes(), &s); err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+			}
+			samples = append(samples, s)
+		}
+		err = sc.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("%s: no samples", path)
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].Time.Before(samples[j].Time) })
+	return samples, nil
+}
+
+// readCSVRecording groups CSV rows with the same time into samples
+func readCSVRecording(f *os.File) ([]recordSample, error) {
+	rd := csv.NewReader(f)
+	rd.FieldsPerRecord = len(csvHeader)
+	rows, err := rd.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	var samples []recordSample
+	for i, row := range rows {
+		if i == 0 && row[0] == csvHeader[0] {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, row[0])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", i+1, err)
+		}
+		num := func(col int) float64 {
+			v, _ := strconv.ParseFloat(row[col], 64)
+			return v
+		}
+		c := recordContainer{Name: row[1], Image: row[2], Project: row[3], Service: row[4],
+			CPU: num(5), MemPerc: num(6), MemUsed: num(7), MemLimit: num(8),
+			NetRx: num(9), NetTx: num(10), BlockRead: num(11), BlockWrite: num(12)}
+		if n := len(samples); n > 0 && samples[n-1].Time.Equal(ts) {
+			samples[n-1].Containers = append(samples[n-1].Containers, c)
+			continue
+		}
+		samples = append(samples, recordSample{Time: ts, Containers: []recordContainer{c}})
+	}
+	return samples, nil
+}
+
+// errReplayEnd is returned by the replay provider after the last sample
+var errReplayEnd = errors.New("end of recording")
+
+// replayProvider plays a recording back one sample per tick
+type replayProvider struct {
+	path    string
+	samples []recordSample
+
+	mu  sync.Mutex
+	pos int // next sample
+}
+
+func newReplayProvider(path string) (*replayProvider, error) {
+	samples, err := loadRecording(path)
+	if err != nil {
+		return nil, err
+	}
+	return &replayProvider{path: path, samples: samples}, nil
+}
+
+func (p *replayProvider) Name() string { return "replay" }
+
+func (p *replayProvider) Snapshot() ([]DockStats, error) {
+	rows, _, _, err := p.next()
+	return rows, err
+}
+
+// next returns the rows, metadata and time of the next sample
+func (p *replayProvider) next() ([]DockStats, map[string]containerMeta, time.Time, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pos >= len(p.samples) {
+		return nil, nil, time.Time{}, errReplayEnd
+	}
+	sample := p.samples[p.pos]
+	p.pos++
+	meta := map[string]containerMeta{}
+	var rows []DockStats
+	for _, c := range sample.Containers {
+		s := DockStats{
+			Name: c.Name, CPU: c.CPU, MemPerc: c.MemPerc, MemUsed: c.MemUsed, MemLimit: c.MemLimit,
+			NetRx: c.NetRx, NetTx: c.NetTx, BlockRead: c.BlockRead, BlockWrite: c.BlockWrite, Read: sample.Time,
+		}
+		s.formatUsage()
+		rows = append(rows, s)
+		meta[c.Name] = containerMeta{Image: c.Image, Project: c.Project, Service: c.Service}
+	}
+	return rows, meta, sample.Time, nil
+}
+
+// progress returns the position for the header
+func (p *replayProvider) progress() (pos, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pos, len(p.samples)
+}
+
+// interval is the typical gap between samples (the median), used as the history step
+func (p *replayProvider) interval() time.Duration {
+	var gaps []time.Duration
+	for i := 1; i < len(p.samples); i++ {
+		if d := p.samples[i].Time.Sub(p.samples[i-1].Time); d > 0 {
+			gaps = append(gaps, d)
+		}
+	}
+	if len(gaps) == 0 {
+		return DefaultMonitorInterval
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
+func (p *replayProvider) Close() error { return nil }
+
+// replaySpeeds are the playback speeds cycled with +/-
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32}
+
+// changeSpeed moves to the next faster ("+") or slower ("-") playback speed
+func (m *monitorModel) changeSpeed(key string) {
+	i := sort.SearchFloat64s(replaySpeeds, m.opts.Speed)
+	if key == "+" || key == "=" {
+		i++
+	} else {
+		i--
+	}
+	m.opts.Speed = replaySpeeds[clamp(i, 0, len(replaySpeeds)-1)]
+}
+
+// toggleRecording starts recording to opts.Record (or a timestamped file) or stops it.
+// Recording to opts.Record again resumes it instead of overwriting what was recorded.
+func (m *monitorModel) toggleRecording() {
+	now := time.Now()
+	if m.recorder != nil {
+		m.stopRecording(now)
+		return
+	}
+	path := m.opts.Record
+	if path == "" {
+		path = defaultRecordPath(now)
+	}
+	r, err := startRecorder(path, m.opts.Record != "")
+	if err != nil {
+		m.status = statusLine{text: "record failed: " + err.Error(), err: true, at: now}
+		return
+	}
+	m.recorder = r
+	m.status = statusLine{text: "recording to " + path, at: now}
+}
+
+func (m *monitorModel) stopRecording(now time.Time) {
+	r := m.recorder
+	m.recorder = nil
+	if err := r.close(); err != nil {
+		m.status = statusLine{text: "record failed: " + err.Error(), err: true, at: now}
+		return
+	}
+	m.status = statusLine{text: fmt.Sprintf("recorded %d samples to %s", r.samples, r.path), at: now}
+}
+
+// record appends the current stats to the open recording
+func (m *monitorModel) record(ts time.Time) {
+	if m.recorder == nil {
+		return
+	}
+	if err := m.recorder.write(ts, m.stats, m.meta); err != nil {
+		m.recorder.close()
+		m.recorder = nil
+		m.status = statusLine{text: "record failed: " + err.Error(), err: true, at: time.Now()}
+	}
+}
+
+// replayLabel describes the playback position for the header
+func (m monitorModel) replayLabel() string {
+	pos, total := m.replay.progress()
+	state := ""
+	if m.replayDone {
+		state = " (end)"
+	} else if m.paused {
+		state = " (paused)"
+	}
+	return fmt.Sprintf("REPLAY %s  %d/%d  ×%g%s", filepath.Base(m.replay.path), pos, total, m.opts.Speed, state)
+}
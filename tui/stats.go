@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatsProvider delivers the latest stats sample of every running container.
+// Streaming providers sample in the background; Snapshot only reads their latest values.
+type StatsProvider interface {
+	Name() string
+	Snapshot() ([]DockStats, error)
+	Close() error
+}
+
+// stats sources accepted by NewStatsProvider
+const (
+	StatsSourceAuto   = "auto"   // engine stream, falling back to the CLI stream
+	StatsSourceEngine = "engine" // Engine API stats stream per container (raw counters)
+	StatsSourceStream = "stream" // one long-lived `docker stats --format '{{json .}}'`
+	StatsSourcePoll   = "poll"   // `docker stats --no-stream` on every tick
+)
+
+// StatsSources lists the valid sources, for completion and validation
+var StatsSources = []string{StatsSourceAuto, StatsSourceEngine, StatsSourceStream, StatsSourcePoll}
+
+// NewStatsProvider starts the given stats source. "auto" prefers the Engine API and
+// falls back to the CLI stream when the daemon socket cannot be reached.
+func NewStatsProvider(source string) (StatsProvider, error) {
+	switch source {
+	case "", StatsSourceAuto:
+		if p, err := newEngineProvider(); err == nil {
+			return p, nil
+		}
+		return newStreamProvider(), nil
+	case StatsSourceEngine:
+		return newEngineProvider()
+	case StatsSourceStream:
+		return newStreamProvider(), nil
+	case StatsSourcePoll:
+		return pollProvider{}, nil
+	}
+	return nil, fmt.Errorf("unknown stats source %q (want %s)", source, strings.Join(StatsSources, ", "))
+}
+
+// pollProvider forks `docker stats --no-stream` for every sample
+type pollProvider struct{}
+
+func (pollProvider) Name() string                   { return StatsSourcePoll }
+func (pollProvider) Snapshot() ([]DockStats, error) { return fetchOnce() }
+func (pollProvider) Close() error                   { return nil }
+
+// ansiEscape matches the cursor and clear-screen sequences docker stats writes between frames
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// streamProvider keeps one `docker stats` process running and reads its JSON lines.
+// Every refresh starts by moving the cursor home, which marks the end of the previous frame.
+type streamProvider struct {
+	mu      sync.Mutex
+	rows    []DockStats
+	seen    map[string]bool // containers reported in the current frame
+	err     error
+	running bool
+	ready   chan struct{} // closed when the first row (or an error) arrives
+	cancel  context.CancelFunc
+}
+
+func newStreamProvider() *streamProvider {
+	p := &streamProvider{}
+	p.start()
+	return p
+}
+
+func (p *streamProvider) Name() string { return StatsSourceStream }
+
+// start launches docker stats unless it is already running; the caller holds no lock
+func (p *streamProvider) start() {
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ready := make(chan struct{})
+	p.cancel, p.ready, p.running, p.err = cancel, ready, true, nil
+	p.seen = map[string]bool{}
+	p.mu.Unlock()
+
+	cmd := exec.CommandContext(ctx, "docker", "stats", "--no-trunc", "--format", "{{json .}}")
+	out, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		p.stop(err, ready)
+		return
+	}
+	go func() {
+		var once sync.Once
+		sc := bufio.NewScanner(out)
+		for sc.Scan() {
+			raw := sc.Text()
+			line := strings.TrimSpace(ansiEscape.ReplaceAllString(raw, ""))
+			p.mu.Lock()
+			if strings.Contains(raw, "\x1b[H") || strings.Contains(raw, "\x1b[2J") {
+				p.endFrame()
+			}
+			if s, ok := parseStreamLine(line); ok {
+				p.upsert(s)
+				once.Do(func() { close(ready) })
+			}
+			p.mu.Unlock()
+		}
+		err := cmd.Wait()
+		if err == nil {
+			err = fmt.Errorf("docker stats exited")
+		}
+		p.stop(err, nil)
+		once.Do(func() { close(ready) })
+	}()
+}
+
+// stop records why the stream ended; the next Snapshot restarts it
+func (p *streamProvider) stop(err error, ready chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running {
+		p.err = err
+	}
+	p.running = false
+	if ready != nil {
+		close(ready)
+	}
+}
+
+// endFrame drops containers that were not reported in the frame that just finished
+func (p *streamProvider) endFrame() {
+	if len(p.seen) == 0 {
+		return
+	}
+	kept := p.rows[:0]
+	for _, s := range p.rows {
+		if p.seen[s.Name] {
+			kept = append(kept, s)
+		}
+	}
+	p.rows = kept
+	p.seen = map[string]bool{}
+}
+
+func (p *streamProvider) upsert(s DockStats) {
+	p.seen[s.Name] = true
+	for i := range p.rows {
+		if p.rows[i].Name == s.Name {
+			p.rows[i] = s
+			return
+		}
+	}
+	p.rows = append(p.rows, s)
+}
+
+func (p *streamProvider) Snapshot() ([]DockStats, error) {
+	p.start()
+	p.mu.Lock()
+	ready := p.ready
+	p.mu.Unlock()
+	select {
+	case <-ready:
+	case <-time.After(3 * time.Second):
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil && !p.running {
+		return nil, p.err
+	}
+	return append([]DockStats(nil), p.rows...), nil
+}
+
+func (p *streamProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running = false
+	p.cancel()
+	return nil
+}
+
+// parseStreamLine reads one `docker stats --format '{{json .}}'` row
+func parseStreamLine(line string) (DockStats, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return DockStats{}, false
+	}
+	var row struct {
+		ID, Name, CPUPerc, MemUsage, MemPerc, NetIO, BlockIO string
+	}
+	if err := json.Unmarshal([]byte(line), &row); err != nil || row.Name == "" {
+		return DockStats{}, false
+	}
+	s := newCLIStats(row.Name, row.CPUPerc, row.MemUsage, row.MemPerc, row.NetIO, row.BlockIO)
+	s.ID = row.ID
+	return s, true
+}
+
+// formatUsage fills the display strings from the byte counters
+func (s *DockStats) formatUsage() {
+	s.MemUsage = binaryBytes(s.MemUsed) + " / " + binaryBytes(s.MemLimit)
+	s.NetIO = humanBytes(s.NetRx) + " / " + humanBytes(s.NetTx)
+	s.BlockIO = humanBytes(s.BlockRead) + " / " + humanBytes(s.BlockWrite)
+}
+
+// newCLIStats builds a sample from the human-readable columns of docker stats
+func newCLIStats(name, cpu, memUsage, memPerc, netIO, blockIO string) DockStats {
+	s := DockStats{
+		Name:     name,
+		CPU:      parsePercent(cpu),
+		MemUsage: memUsage,
+		MemPerc:  parsePercent(memPerc),
+		NetIO:    netIO,
+		BlockIO:  blockIO,
+		Read:     time.Now(),
+	}
+	s.MemUsed, s.MemLimit = parseTwoBytes(memUsage)
+	s.NetRx, s.NetTx = parseTwoBytes(netIO)
+	s.BlockRead, s.BlockWrite = parseTwoBytes(blockIO)
+	return s
+}
@@ -0,0 +1,369 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"docsh/internal/compose"
+	"docsh/internal/health"
+)
+
+// sort keys, cycled with "o"
+const (
+	sortDocker = iota // the order docker stats returns
+	sortCPU
+	sortMem
+	sortNet
+	sortDisk
+	sortName
+)
+
+var sortNames = []string{"docker", "cpu", "mem", "net", "disk", "name"}
+
+// noProject is the group of containers without compose labels
+const noProject = "(no project)"
+
+// metaRefreshInterval is how often image and label metadata is refreshed
+const metaRefreshInterval = 30 * time.Second
+
+// containerMeta is what docker stats does not report but filtering and grouping need
+type containerMeta struct {
+	Image   string
+	Labels  map[string]string
+	Project string
+	Service string
+
+	RestartCount int
+	Health       string // starting, healthy or unhealthy; empty without a health check
+}
+
+type metaMsg struct {
+	meta map[string]containerMeta
+	err  error
+}
+
+// fetchMetaCmd inspects all running containers in one docker call
+func fetchMetaCmd() tea.Cmd {
+	return func() tea.Msg {
+		ids, err := runDocker("ps", "-q")
+		if err != nil {
+			return metaMsg{err: err}
+		}
+		meta := map[string]containerMeta{}
+		if strings.TrimSpace(ids) == "" {
+			return metaMsg{meta: meta}
+		}
+		out, err := runDocker(append([]string{"inspect"}, strings.Fields(ids)...)...)
+		if err != nil {
+			return metaMsg{err: err}
+		}
+		var list []struct {
+			Name         string
+			RestartCount int
+			Config       struct {
+				Image  string
+				Labels map[string]string
+			}
+			State struct {
+				Health *struct{ Status string }
+			}
+		}
+		if err := json.Unmarshal([]byte(out), &list); err != nil {
+			return metaMsg{err: err}
+		}
+		for _, c := range list {
+			name := strings.TrimPrefix(c.Name, "/")
+			project, service := compose.FromLabels(name, c.Config.Labels)
+			cm := containerMeta{Image: c.Config.Image, Labels: c.Config.Labels, Project: project, Service: service, RestartCount: c.RestartCount}
+			if c.State.Health != nil {
+				cm.Health = c.State.Health.Status
+			}
+			meta[name] = cm
+		}
+		return metaMsg{meta: meta}
+	}
+}
+
+// needsMeta reports whether metadata is stale or missing for a listed container
+func (m monitorModel) needsMeta() bool {
+	if m.metaFetching || m.replay != nil {
+		return false
+	}
+	if time.Since(m.metaAt) > m.metaInterval() {
+		return true
+	}
+	for _, s := range m.stats {
+		if _, ok := m.meta[s.Name]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFilter checks the "/" filter against name, image and labels.
+// "name:", "image:" and "label:" prefixes restrict the match to one field;
+// a label filter of key=value matches the key exactly and the value as a substring.
+func matchesFilter(filter string, s DockStats, meta containerMeta) bool {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if filter == "" {
+		return true
+	}
+	field, text := "", filter
+	if f, t, ok := strings.Cut(filter, ":"); ok && (f == "name" || f == "image" || f == "label") {
+		field, text = f, t
+	}
+	nameMatch := strings.Contains(strings.ToLower(s.Name), text)
+	imageMatch := strings.Contains(strings.ToLower(meta.Image), text)
+	labelMatch := false
+	key, value, hasValue := strings.Cut(text, "=")
+	for k, v := range meta.Labels {
+		k, v = strings.ToLower(k), strings.ToLower(v)
+		if hasValue && k == key && strings.Contains(v, value) || !hasValue && (strings.Contains(k, text) || strings.Contains(v, text)) {
+			labelMatch = true
+			break
+		}
+	}
+	switch field {
+	case "name":
+		return nameMatch
+	case "image":
+		return imageMatch
+	case "label":
+		return labelMatch
+	}
+	return nameMatch || imageMatch || labelMatch
+}
+
+// statsGroup is one compose project in group mode (or every container when not grouping)
+type statsGroup struct {
+	Project string
+	Members []DockStats
+	CPU     float64
+	MemPerc float64
+	Mem     float64 // bytes used
+	Net     float64 // bytes/sec
+	Disk    float64 // bytes/sec
+}
+
+// sortValue is the value a container or group is ordered by (larger first, except name)
+func (m monitorModel) sortValue(s DockStats) float64 {
+	switch m.sortKey {
+	case sortCPU:
+		return s.CPU
+	case sortMem:
+		return s.MemUsed
+	case sortNet:
+		return lastValue(m.netHist[s.Name])
+	case sortDisk:
+		return lastValue(m.diskHist[s.Name])
+	}
+	return 0
+}
+
+func (g statsGroup) sortValue(key int) float64 {
+	switch key {
+	case sortCPU:
+		return g.CPU
+	case sortMem:
+		return g.Mem
+	case sortNet:
+		return g.Net
+	case sortDisk:
+		return g.Disk
+	}
+	return 0
+}
+
+// visibleGroups filters and sorts the containers, grouping them by compose project in group mode
+func (m monitorModel) visibleGroups() []statsGroup {
+	var rows []DockStats
+	for _, s := range m.stats {
+		if matchesFilter(m.filter, s, m.meta[s.Name]) {
+			rows = append(rows, s)
+		}
+	}
+	if m.sortKey != sortDocker {
+		sort.SliceStable(rows, func(i, j int) bool {
+			if m.sortKey == sortName {
+				return (rows[i].Name < rows[j].Name) != m.sortReverse
+			}
+			return (m.sortValue(rows[i]) > m.sortValue(rows[j])) != m.sortReverse
+		})
+	}
+	if !m.groupByProject {
+		return []statsGroup{{Members: rows}}
+	}
+
+	index := map[string]int{}
+	var groups []statsGroup
+	for _, s := range rows {
+		project := m.meta[s.Name].Project
+		if project == "" {
+			project = noProject
+		}
+		i, ok := index[project]
+		if !ok {
+			i = len(groups)
+			index[project] = i
+			groups = append(groups, statsGroup{Project: project})
+		}
+		g := &groups[i]
+		g.Members = append(g.Members, s)
+		g.CPU += s.CPU
+		g.MemPerc += s.MemPerc
+		g.Mem += s.MemUsed
+		g.Net += lastValue(m.netHist[s.Name])
+		g.Disk += lastValue(m.diskHist[s.Name])
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		// containers without a project always go last
+		if (groups[i].Project == noProject) != (groups[j].Project == noProject) {
+			return groups[j].Project == noProject
+		}
+		if m.sortKey == sortDocker || m.sortKey == sortName {
+			return (groups[i].Project < groups[j].Project) != (m.sortReverse && m.sortKey == sortName)
+		}
+		return (groups[i].sortValue(m.sortKey) > groups[j].sortValue(m.sortKey)) != m.sortReverse
+	})
+	return groups
+}
+
+// visible returns the listed containers in display order; m.selected indexes into it
+func (m monitorModel) visible() []DockStats {
+	var rows []DockStats
+	for _, g := range m.visibleGroups() {
+		rows = append(rows, g.Members...)
+	}
+	return rows
+}
+
+// reselect keeps the selection on the same container after the list was re-ordered
+func (m *monitorModel) reselect(name string) {
+	rows := m.visible()
+	for i, s := range rows {
+		if s.Name == name {
+			m.selected = i
+			return
+		}
+	}
+	m.selected = clamp(m.selected, 0, max(len(rows)-1, 0))
+}
+
+// handleFilterKey edits the "/" filter; Enter keeps it, Esc clears it
+func (m monitorModel) handleFilterKey(msg tea.KeyMsg) (monitorModel, tea.Cmd) {
+	prev := m.selectedName()
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filterInput = false
+	case tea.KeyEsc:
+		m.filterInput = false
+		m.filter = ""
+	case tea.KeyBackspace:
+		if r := []rune(m.filter); len(r) > 0 {
+			m.filter = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	}
+	m.reselect(prev)
+	return m, nil
+}
+
+var (
+	tableHeaderStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+	groupStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+)
+
+// tableRow formats one row of the compact table
+func tableRow(nameWidth int, name, cpu, memPerc, mem, net, disk, badge string) string {
+	return fmt.Sprintf("%-*s %7s %7s %10s %11s %11s %s", nameWidth, truncateRunes(name, nameWidth), cpu, memPerc, mem, net, disk, badge)
+}
+
+// healthBadge returns the health and restart marker of a running container, plain and
+// colored ("" when it has no health check and never restarted)
+func healthBadge(meta containerMeta) (plain, colored string) {
+	st := health.Status{State: "running", Health: meta.Health, RestartCount: meta.RestartCount}
+	plain = st.Badge()
+	if plain == "" {
+		return "", ""
+	}
+	return plain, lipgloss.NewStyle().Foreground(eventColors[st.Color()]).Render(plain)
+}
+
+// renderTable draws one line per container, with project aggregate rows in group mode.
+// It shows the page holding the selection and returns the page label ("" when it all fits).
+func (m monitorModel) renderTable(width, rows int) (string, string) {
+	nameWidth := clamp(width-69, 12, 48)
+	var lines []string
+	selLine := 0
+	n := 0
+	for _, g := range m.visibleGroups() {
+		if m.groupByProject {
+			label := fmt.Sprintf("▾ %s (%d)", g.Project, len(g.Members))
+			lines = append(lines, groupStyle.Render(tableRow(nameWidth, label,
+				fmt.Sprintf("%.1f%%", g.CPU), fmt.Sprintf("%.1f%%", g.MemPerc), humanBytes(g.Mem),
+				humanBytes(g.Net)+"/s", humanBytes(g.Disk)+"/s", "")))
+		}
+		for _, s := range g.Members {
+			name := s.Name
+			if m.groupByProject {
+				name = "  " + name
+				if svc := m.meta[s.Name].Service; svc != "" && svc != s.Name {
+					name += " (" + svc + ")"
+				}
+			}
+			if m.alertTags(s.Name) != "" {
+				name = "⚠ " + name
+			}
+			plain, colored := healthBadge(m.meta[s.Name])
+			row := func(badge string) string {
+				return tableRow(nameWidth, name,
+					fmt.Sprintf("%.1f%%", s.CPU), fmt.Sprintf("%.1f%%", s.MemPerc), humanBytes(s.MemUsed),
+					humanBytes(lastValue(m.netHist[s.Name]))+"/s", humanBytes(lastValue(m.diskHist[s.Name]))+"/s", badge)
+			}
+			var text string
+			if n == m.selected {
+				selLine = len(lines)
+				text = selStyle.Render(row(plain))
+			} else if strings.HasPrefix(name, "⚠ ") {
+				text = alertStyle.Render(row(plain))
+			} else {
+				text = row(colored)
+			}
+			lines = append(lines, text)
+			n++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(tableHeaderStyle.Render(tableRow(nameWidth, "NAME", "CPU", "MEM%", "MEM", "NET", "DISK", "HEALTH")))
+	b.WriteString("\n")
+	rows = max(rows-1, 1)
+	start, page, pages := pageOf(selLine, len(lines), rows)
+	for _, l := range lines[start:min(start+rows, len(lines))] {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+	return b.String(), pageLabel(page, pages)
+}
+
+// pageOf returns the first item of the page holding index, the page number and the page count
+func pageOf(index, total, perPage int) (start, page, pages int) {
+	perPage = max(perPage, 1)
+	pages = max((total+perPage-1)/perPage, 1)
+	page = clamp(index/perPage, 0, pages-1)
+	return page * perPage, page, pages
+}
+
+func pageLabel(page, pages int) string {
+	if pages <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("page %d/%d", page+1, pages)
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"os"
+	"time"
+
+	"docsh/internal/alert"
+)
+
+// Watcher samples stats and evaluates alert rules without a UI (the watch command).
+// It drives the same model as the monitor, so rules see the same history.
+type Watcher struct {
+	m monitorModel
+}
+
+// NewWatcher starts the stats source for headless alerting
+func NewWatcher(opts MonitorOptions) (*Watcher, error) {
+	provider, err := NewStatsProvider(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{m: newModel(opts, provider)}, nil
+}
+
+// Interval is the time between two Step calls
+func (w *Watcher) Interval() time.Duration { return w.m.opts.Interval }
+
+// Source names the stats source in use
+func (w *Watcher) Source() string { return w.m.provider.Name() }
+
+// Step takes one sample, refreshes inspect data when it is due, notifies and
+// returns the alerts that started firing or were resolved
+func (w *Watcher) Step() ([]alert.Event, error) {
+	rows, err := w.m.provider.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	events := w.m.applyStats(statsMsg{rows: rows, ts: time.Now()})
+	if w.m.needsMeta() {
+		if msg, ok := fetchMetaCmd()().(metaMsg); ok {
+			events = append(events, w.m.applyMeta(msg)...)
+		}
+	}
+	for _, e := range events {
+		if err := w.m.opts.Alerts.Notify(e, os.Stdout); err != nil {
+			return events, err
+		}
+	}
+	return events, nil
+}
+
+// Containers is the number of containers in the last sample
+func (w *Watcher) Containers() int { return len(w.m.stats) }
+
+// Active returns the alerts currently firing
+func (w *Watcher) Active() []alert.Event {
+	if w.m.alerts == nil {
+		return nil
+	}
+	return w.m.alerts.Active()
+}
+
+func (w *Watcher) Close() error { return w.m.provider.Close() }